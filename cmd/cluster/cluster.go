@@ -0,0 +1,141 @@
+package cluster
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/darimuri/coll-news/pkg/cluster"
+	"github.com/darimuri/coll-news/pkg/coll"
+	"github.com/darimuri/coll-news/pkg/snapshot"
+	"github.com/darimuri/coll-news/pkg/store"
+)
+
+const hourFormat = "2006010215"
+
+var (
+	collectType          string
+	collectDirectoryPath string
+	hour                 string
+	outputFormat         string
+	save                 bool
+)
+
+var Command = &cobra.Command{
+	Use:   "cluster",
+	Short: "Cluster stories daum and naver list in an hour and report how they cover and rank them",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return clusterHour()
+	},
+
+	Args: func(cmd *cobra.Command, args []string) error {
+		return validateFlags()
+	},
+}
+
+func init() {
+	Command.Flags().StringVarP(&collectType, "collect-type", "t", "", fmt.Sprintf("collect news type(%s)", coll.Types))
+	Command.Flags().StringVarP(&collectDirectoryPath, "save-directory-path", "d", "", "save path of collected data")
+	Command.Flags().StringVarP(&hour, "hour", "", "", "hour of runs(e.g. 2021101604), the last complete run of every source in it is clustered")
+	Command.Flags().StringVarP(&outputFormat, "output", "o", snapshot.FormatText, fmt.Sprintf("output format(%s)", snapshot.FormatsDesc))
+	Command.Flags().BoolVarP(&save, "save", "", false, "save cluster ids as cluster_id of news in the json.gz dumps of the runs and the report as <run>.clusters.json next to them")
+
+	//goland:noinspection GoUnhandledErrorResult
+	Command.MarkFlagRequired("collect-type")
+	//goland:noinspection GoUnhandledErrorResult
+	Command.MarkFlagRequired("save-directory-path")
+	//goland:noinspection GoUnhandledErrorResult
+	Command.MarkFlagRequired("hour")
+
+	log.SetFlags(log.LstdFlags | log.Lmicroseconds | log.Lshortfile)
+}
+
+func clusterHour() error {
+	started, err := time.ParseInLocation(hourFormat, hour, time.Local)
+	if err != nil {
+		return err
+	}
+
+	runs := make([]store.Run, 0)
+	sources := make([]cluster.Source, 0)
+
+	for _, source := range []string{coll.Daum, coll.Naver} {
+		run, errRun := lastRunIn(filepath.Join(collectDirectoryPath, source, collectType), started, started.Add(time.Hour))
+		if errRun != nil {
+			return errRun
+		}
+
+		news, errRead := store.ReadJsonGzip(run.GzipDumpFile())
+		if errRead != nil {
+			return errRead
+		}
+
+		runs = append(runs, run)
+		sources = append(sources, cluster.Source{Name: source, Run: run.FilePrefix(), News: news})
+	}
+
+	r := cluster.Build(sources)
+
+	if true == save {
+		for i, run := range runs {
+			if err = store.WriteJsonGzip(sources[i].News, run.GzipDumpFile()); err != nil {
+				return err
+			}
+			if err = cluster.Save(r, run.ClustersFile()); err != nil {
+				return err
+			}
+		}
+		log.Printf("saved ids of %d numbers of clusters to runs of %s\n", len(r.Clusters), hour)
+	}
+
+	out, err := cluster.Format(r, outputFormat)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(out)
+
+	return nil
+}
+
+// lastRunIn finds the last complete run under rootPath started from from until to.
+func lastRunIn(rootPath string, from, to time.Time) (store.Run, error) {
+	runs, err := store.Runs(rootPath)
+	if err != nil {
+		return store.Run{}, err
+	}
+
+	for i := len(runs) - 1; i >= 0; i-- {
+		run := runs[i]
+		if run.Partial || run.Started.Before(from) || false == run.Started.Before(to) {
+			continue
+		}
+
+		return run, nil
+	}
+
+	return store.Run{}, fmt.Errorf("no complete run is found in %s under %s", from.Format(hourFormat), rootPath)
+}
+
+func validateFlags() error {
+	switch collectType {
+	case coll.PC, coll.Mobile:
+	default:
+		return fmt.Errorf("type should be %s. not %s", coll.Types, collectType)
+	}
+
+	if _, err := time.Parse(hourFormat, hour); err != nil {
+		return fmt.Errorf("hour %s should be formatted as %s: %v", hour, hourFormat, err)
+	}
+
+	switch outputFormat {
+	case snapshot.FormatText, snapshot.FormatMD, snapshot.FormatJSON:
+	default:
+		return fmt.Errorf("output should be %s. not %s", snapshot.FormatsDesc, outputFormat)
+	}
+
+	return nil
+}
@@ -1,18 +1,14 @@
 package coll
 
 import (
-	"bytes"
-	"compress/gzip"
-	"encoding/csv"
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
-	"io/ioutil"
 	"log"
 	"os"
 	"os/signal"
 	"path/filepath"
 	"runtime"
-	"strings"
 	"syscall"
 	"time"
 
@@ -22,53 +18,15 @@ import (
 	"github.com/spf13/cobra"
 	"golang.org/x/sys/unix"
 
+	"github.com/darimuri/coll-news/pkg/adaptor"
+	"github.com/darimuri/coll-news/pkg/cache"
 	"github.com/darimuri/coll-news/pkg/coll"
+	"github.com/darimuri/coll-news/pkg/drift"
+	"github.com/darimuri/coll-news/pkg/headline"
+	"github.com/darimuri/coll-news/pkg/metrics"
+	"github.com/darimuri/coll-news/pkg/store"
 	"github.com/darimuri/coll-news/pkg/types"
-)
-
-const (
-	listTypesDesc = "t(tsv)/m(markdown table)/b(both)"
-
-	listTypeBoth = "b"
-	listTypeTsv  = "t"
-	listTypeMD   = "m"
-)
-
-var availableListTypes = map[string]string{
-	listTypeBoth: listTypeBoth,
-	listTypeTsv:  listTypeTsv,
-	listTypeMD:   listTypeMD,
-}
-
-var (
-	listHeader = []string{
-		"No.",
-		"NumComment",
-		"Author",
-		"Publisher",
-		"Category",
-		"Title",
-		"Location",
-		"CollectedAt",
-		"PostedAt",
-		"ModifiedAt",
-		"Emotions",
-		"URL",
-	}
-	listHeaderLine = []string{
-		"---",
-		"---",
-		"---",
-		"---",
-		"---",
-		"---",
-		"---",
-		"---",
-		"---",
-		"---",
-		"---",
-		"---",
-	}
+	"github.com/darimuri/coll-news/pkg/util"
 )
 
 var (
@@ -78,15 +36,35 @@ var (
 	collectDirectoryPath   string
 	listOutputFormat       string
 	chromeBin              string
+	recordDirectoryPath    string
+	endCacheURL            string
+	replayDirectoryPath    string
+	selectorPackDir        string
+	commentSort            string
 	disableHeadless        bool
 	endGetIgnoreError      bool
 	enableChromeLogging    bool
 	stopAfterCollect       bool
+	keepVersions           bool
 	listGetRetryCount      int
+	endConcurrency         int
 	chromeLoggingVerbosity int
 	metricsPort            int
+	commentLimit           int
+	readyPeriods           int
+	maxConsecutiveFailures int
+	endCacheTTL            string
+	endCacheTTLs           cache.TTLs
+	retryBackoffInitial    time.Duration
+	retryBackoffMax        time.Duration
+	shutdownGracePeriod    time.Duration
+	revisitPeriod          time.Duration
+	revisitWindow          time.Duration
+	revisitLimit           int
 )
 
+const userDataRoot = "/tmp/rod"
+
 var Command = &cobra.Command{
 	Use:   "coll",
 	Short: "Collect portal news in a given period",
@@ -105,14 +83,31 @@ func init() {
 	Command.Flags().StringVarP(&collectType, "collect-type", "t", "", fmt.Sprintf("collect news type(%s)", coll.Types))
 	Command.Flags().StringVarP(&collectSource, "collect-news-source", "s", "", fmt.Sprintf("news source(%s)", coll.Sources))
 	Command.Flags().StringVarP(&collectDirectoryPath, "save-directory-path", "d", "", "save path for collected data")
-	Command.Flags().StringVarP(&listOutputFormat, "list-output-format", "f", "b", fmt.Sprintf("list output format of collected news(%s)", listTypesDesc))
+	Command.Flags().StringVarP(&listOutputFormat, "list-output-format", "f", "b", fmt.Sprintf("list output format of collected news(%s)", store.ListTypesDesc))
 	Command.Flags().BoolVarP(&disableHeadless, "no-headless", "n", false, "collect news in non-headless mode")
 	Command.Flags().BoolVarP(&endGetIgnoreError, "end-get-ignore-error", "e", false, "continue collect end when error occurs")
 	Command.Flags().IntVarP(&listGetRetryCount, "list-get-retry-count", "l", 0, "retry count while getting list")
+	Command.Flags().StringVarP(&endCacheURL, "cache", "", "", fmt.Sprintf("cache of collected ends kept across collections(%s, default is file cache under the save path of source and type)", cache.URLDesc))
+	Command.Flags().StringVarP(&endCacheTTL, "end-cache-ttl", "", cache.DefaultTTL.String(), "time to reuse a collected end before it is collected again, for every source(e.g. 3m) or by source(e.g. daum=3m,naver=10m)")
+	Command.Flags().IntVarP(&endConcurrency, "end-concurrency", "", 1, "number of browser tabs collecting news ends at once")
 	Command.Flags().BoolVarP(&enableChromeLogging, "enable-chrome-logging", "", false, "run chrome using --enable-logging")
 	Command.Flags().IntVarP(&chromeLoggingVerbosity, "chrome-logging-verbosity", "", 1, "run chrome using --v=1")
-	Command.Flags().IntVarP(&metricsPort, "metrics-port", "", 3000, "port for golang metrics")
+	Command.Flags().IntVarP(&metricsPort, "metrics-port", "", 3000, "port for golang metrics, /healthz and /readyz")
+	Command.Flags().IntVarP(&readyPeriods, "ready-periods", "", 3, "number of collect periods /readyz waits for a successful collection")
+	Command.Flags().IntVarP(&maxConsecutiveFailures, "max-consecutive-failures", "", 5, "number of failed collections in a row before the process exits")
+	Command.Flags().DurationVarP(&retryBackoffInitial, "retry-backoff", "", time.Second*30, "wait before retrying a failed collection, doubled for every failure in a row")
+	Command.Flags().DurationVarP(&retryBackoffMax, "retry-backoff-max", "", time.Minute*10, "maximum wait before retrying a failed collection")
+	Command.Flags().DurationVarP(&shutdownGracePeriod, "shutdown-grace-period", "", time.Minute, "time to wait a running collection to save what it collected, and a running revisit to close its browser, after SIGINT/SIGTERM")
 	Command.Flags().BoolVarP(&stopAfterCollect, "stop-after-collect", "", false, "stop process after collect once")
+	Command.Flags().StringVarP(&recordDirectoryPath, "record", "", "", "record every response of the browser to the directory")
+	Command.Flags().StringVarP(&replayDirectoryPath, "replay", "", "", "serve the browser with responses recorded in the directory instead of network")
+	Command.Flags().StringVarP(&selectorPackDir, "selector-pack-dir", "", "", "directory of selector packs(e.g. daum-pc.yaml) used instead of the built-in ones")
+	Command.Flags().DurationVarP(&revisitPeriod, "revisit-period", "", 0, "period to collect ends of news seen before again for their engagement time series, no revisit for 0")
+	Command.Flags().DurationVarP(&revisitWindow, "revisit-window", "", time.Hour*24, "time since news are first seen while they are revisited")
+	Command.Flags().IntVarP(&revisitLimit, "revisit-limit", "", 100, "maximum number of news ends collected again every revisit-period, the longest unvisited first")
+	Command.Flags().BoolVarP(&keepVersions, "keep-versions", "", false, "keep every version of news ends with diffs when they are collected again edited")
+	Command.Flags().IntVarP(&commentLimit, "comments", "", 0, "number of top comments collected with every news end, none for 0")
+	Command.Flags().StringVarP(&commentSort, "comment-sort", "", types.CommentSortRecommended, fmt.Sprintf("order of the top comments(%s, %s)", types.CommentSortRecommended, types.CommentSortLatest))
 
 	//goland:noinspection GoUnhandledErrorResult
 	Command.MarkFlagRequired("collect-type")
@@ -125,16 +120,28 @@ func init() {
 }
 
 func collect() error {
+	savePath := filepath.Join(collectDirectoryPath, collectSource, collectType)
+
 	ec := echo.New()
 	go func() {
 		ec.Use(middleware.Recover())
 		ec.GET("/metrics", echo.WrapHandler(promhttp.Handler()))
+		ec.GET("/healthz", status.healthz)
+		ec.GET("/readyz", status.readyz(savePath))
 		if err := ec.Start(fmt.Sprintf(":%d", metricsPort)); err != nil {
 			panic(err)
 		}
 	}()
 
-	savePath := filepath.Join(collectDirectoryPath, collectSource, collectType)
+	endCache, errCache := newEndCache(savePath)
+	if errCache != nil {
+		return errCache
+	}
+
+	m := metrics.NewCollection(collectSource, collectType)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
 	s := make(chan os.Signal, 1)
 	e := make(chan error, 1)
@@ -145,27 +152,74 @@ func collect() error {
 	nextTrigger := finished
 
 	if true == stopAfterCollect {
-		return collectAndSave(savePath, collectSource, collectType)
+		go func() {
+			e <- collectAndSave(ctx, savePath, collectSource, collectType, endCache, m, 0)
+		}()
+
+		select {
+		case collErr := <-e:
+			return collErr
+		case sig := <-s:
+			log.Println("stop collection with signal", sig)
+			cancel()
+			return waitCollection(e, s)
+		}
 	}
 
+	// closed when revisits stop, so their browser is cleaned up before the process exits
+	revisitDone := make(chan struct{})
+	if revisitPeriod > 0 {
+		go func() {
+			defer close(revisitDone)
+			revisitEvery(ctx, savePath, endCache, m)
+		}()
+	} else {
+		close(revisitDone)
+	}
+
+	var failures int
+
 	for {
 		select {
 		case sig := <-s:
 			log.Println("stop collection with signal", sig)
+			cancel()
+			if finished.IsZero() {
+				if collErr := waitCollection(e, s); collErr != nil {
+					log.Println("collection is stopped with error", collErr.Error())
+				}
+			}
+			if revisitErr := waitRevisit(revisitDone, s); revisitErr != nil {
+				log.Println("revisit is stopped with error", revisitErr.Error())
+			}
 			ec.Close()
 			os.Exit(0)
 		case <-time.After(time.Second):
 			if false == finished.IsZero() && nextTrigger.Before(time.Now()) {
 				finished = time.Time{}
 				nextTrigger = time.Now().Add(collectPeriod)
-				go func() {
-					e <- collectAndSave(savePath, collectSource, collectType)
-				}()
+				go func(failures int) {
+					e <- collectAndSave(ctx, savePath, collectSource, collectType, endCache, m, failures)
+				}(failures)
 			}
 		case collErr := <-e:
 			if collErr != nil {
-				log.Println("failed to collect for error", collErr.Error())
-				os.Exit(1)
+				failures++
+				log.Printf("failed to collect for error %s. %d failures in a row\n", collErr.Error(), failures)
+
+				if failures >= maxConsecutiveFailures {
+					log.Printf("stop collection after %d failures in a row\n", failures)
+					cancel()
+					if revisitErr := waitRevisit(revisitDone, s); revisitErr != nil {
+						log.Println("revisit is stopped with error", revisitErr.Error())
+					}
+					ec.Close()
+					os.Exit(1)
+				}
+
+				nextTrigger = time.Now().Add(retryBackoff(failures))
+			} else {
+				failures = 0
 			}
 			if nextTrigger.After(time.Now()) {
 				log.Println("next collection will start at", nextTrigger.Format(types.LogDateTimeFormat))
@@ -177,31 +231,85 @@ func collect() error {
 	return nil
 }
 
-func collectAndSave(rootPath string, collectSource string, collectType string) (retErr error) {
+// waitCollection waits the running collection to save what it collected until the grace period passes
+// or another signal arrives.
+func waitCollection(e <-chan error, s <-chan os.Signal) error {
+	log.Println("wait running collection to stop for", shutdownGracePeriod)
+
+	select {
+	case collErr := <-e:
+		return collErr
+	case sig := <-s:
+		return fmt.Errorf("collection is abandoned with signal %s", sig)
+	case <-time.After(shutdownGracePeriod):
+		return fmt.Errorf("collection is not stopped within %s", shutdownGracePeriod)
+	}
+}
+
+// waitRevisit waits the revisit round running to clean up its browser until the grace period passes or another
+// signal arrives.
+func waitRevisit(done <-chan struct{}, s <-chan os.Signal) error {
+	select {
+	case <-done:
+		return nil
+	default:
+	}
+
+	log.Println("wait running revisit to stop for", shutdownGracePeriod)
+
+	select {
+	case <-done:
+		return nil
+	case sig := <-s:
+		return fmt.Errorf("revisit is abandoned with signal %s", sig)
+	case <-time.After(shutdownGracePeriod):
+		return fmt.Errorf("revisit is not stopped within %s", shutdownGracePeriod)
+	}
+}
+
+// collectAndSave runs a collection. failures is the number of collections failed in a row before this one.
+// When ctx is done, it stops after the current item and saves what it collected as a partial run.
+func collectAndSave(ctx context.Context, rootPath string, collectSource string, collectType string, endCache cache.Cache, m *metrics.Collection, failures int) (retErr error) {
 	started := nowInLocalZone()
 
 	log.Println("collect news", collectSource, collectType, "to", rootPath)
 
-	dumpPath := filepath.Join(rootPath, "dump", started.Format(types.FileYearFormat))
-	fullDumpPath := filepath.Join(dumpPath, started.Format(types.FileDateFormat))
-	listPath := filepath.Join(rootPath, "list", started.Format(types.FileYearFormat), started.Format(types.FileDateFormat))
+	run := store.Run{RootPath: rootPath, Started: started}
+	listPath := run.ListPath()
 
-	gzipDumpFile := filepath.Join(fullDumpPath, fmt.Sprintf("%s.%s", toFilePrefix(started), "json.gz"))
+	rec := drift.NewRecorder()
+	ctx = drift.WithRecorder(ctx, rec)
+
+	m.RunStarted()
+	defer func() {
+		m.RunFinished(retErr)
+		status.end(retErr)
+
+		if ctx.Err() == nil {
+			checkDrift(rootPath, run, rec, m, retErr == nil)
+		}
+
+		if retErr == nil || ctx.Err() != nil {
+			return
+		}
+
+		if err := store.WriteFailure(run, collectSource, collectType, failures+1, retErr); err != nil {
+			log.Println("failed to write failure of run", run.FilePrefix(), "for", err)
+		}
+	}()
 
 	if errMkdir := checkDirWritable(listPath); errMkdir != nil {
 		return errMkdir
 	}
 
-	option := coll.Option{
-		SavePath:    dumpPath,
-		Headless:    !disableHeadless,
-		Logging:     enableChromeLogging,
-		LogLevel:    chromeLoggingVerbosity,
-		UserDataDir: filepath.Join("/tmp/rod", collectSource, collectType),
-	}
+	option := collectorOption(run.DumpPath(), filepath.Join(userDataRoot, collectSource, collectType), endCache)
 
-	if chromeBin != "" {
-		option.ChromeBin = chromeBin
+	if failures > 0 {
+		// a crashed chrome may leave its profile locked or broken, start over with a fresh one
+		log.Println("remove user data of chrome", option.UserDataDir, "after", failures, "failed collections")
+		if err := os.RemoveAll(option.UserDataDir); err != nil {
+			log.Println("failed to remove user data of chrome for", err)
+		}
 	}
 
 	c, errColl := coll.NewCollector(collectSource, collectType, option)
@@ -209,21 +317,20 @@ func collectAndSave(rootPath string, collectSource string, collectType string) (
 		return errColl
 	}
 
+	status.begin(c)
+
 	defer func() {
 		if r := recover(); r != nil {
 			switch v := r.(type) {
 			case error:
 				retErr = v
-				return
 			default:
 				retErr = fmt.Errorf("unknown panic cause %+v", v)
-				return
 			}
 		}
 
-		if c != nil {
-			c.Cleanup()
-		}
+		status.release()
+		cleanup(c)
 	}()
 
 	news := make([]types.News, 0)
@@ -233,14 +340,15 @@ func collectAndSave(rootPath string, collectSource string, collectType string) (
 	var topNews, homeNews []types.News
 	var err error
 
+	phaseStarted := time.Now()
 	for {
 		log.Printf("get top news list for error count(%d) < retry count(%d)\n", listGetErrorCount, listGetRetryCount)
 
-		c.Top()
+		c.Top(ctx)
 		collectedAt = nowInLocalZone().Format(types.DataDateTimeFormat)
-		topNews, err = c.GetTopNewsList()
+		topNews, err = c.GetTopNewsList(ctx)
 
-		if err == nil {
+		if err == nil || ctx.Err() != nil {
 			break
 		}
 
@@ -254,22 +362,21 @@ func collectAndSave(rootPath string, collectSource string, collectType string) (
 
 		return err
 	}
+	m.ObservePhase(metrics.PhaseTop, phaseStarted)
 
-	//TODO: daum pc GetNewsHomeNewsList error should be fixed.
-	// https://github.com/darimuri/coll-news/issues/8
-	// skip while this issue is resolved
 	listGetErrorCount = 0
-	if collectSource == coll.Daum && collectType == coll.PC {
-		log.Println("skip news home news list for https://github.com/darimuri/coll-news/issues/8")
+	if ctx.Err() != nil {
+		log.Println("skip news home news list for", ctx.Err())
 	} else {
+		phaseStarted = time.Now()
 		for {
 			log.Printf("get news home news list for error count(%d) < retry count(%d)\n", listGetErrorCount, listGetRetryCount)
 
-			c.NewsHome()
+			c.NewsHome(ctx)
 			collectedAt = time.Now().Format(types.DataDateTimeFormat)
-			homeNews, err = c.GetNewsHomeNewsList()
+			homeNews, err = c.GetNewsHomeNewsList(ctx)
 
-			if err == nil {
+			if err == nil || ctx.Err() != nil {
 				break
 			}
 
@@ -283,6 +390,7 @@ func collectAndSave(rootPath string, collectSource string, collectType string) (
 
 			return err
 		}
+		m.ObservePhase(metrics.PhaseNewsHome, phaseStarted)
 	}
 
 	for i := range topNews {
@@ -298,11 +406,18 @@ func collectAndSave(rootPath string, collectSource string, collectType string) (
 	news = append(news, topNews...)
 	news = append(news, homeNews...)
 
+	m.SetItems(news)
+
 	log.Printf("get %d numbers of news ends\n", len(news))
 
+	phaseStarted = time.Now()
+	endErrs := c.GetNewsEnds(ctx, news, endConcurrency, false == endGetIgnoreError)
+	m.ObservePhase(metrics.PhaseEnds, phaseStarted)
+
 	for idx := range news {
-		if err = c.GetNewsEnd(&news[idx]); err != nil {
-			if false == endGetIgnoreError {
+		if err = endErrs[idx]; err != nil && err != ctx.Err() {
+			m.EndFailed(err)
+			if false == endGetIgnoreError && false == errors.Is(err, adaptor.OutOfPortal) {
 				return err
 
 			}
@@ -312,152 +427,117 @@ func collectAndSave(rootPath string, collectSource string, collectType string) (
 		if news[idx].End != nil {
 			news[idx].End.HTML = ""
 		}
+	}
 
-		if idx > 10 && idx%10 == 1 {
-			log.Printf("processed %d percent of news end\n", (idx*100)/len(news))
+	if keepVersions {
+		if err = recordVersions(rootPath, news); err != nil {
+			log.Println("failed to record versions of news ends for", err)
 		}
 	}
 
-	tableRows := toTable(news)
+	if ctx.Err() != nil {
+		run.Partial = true
+		log.Printf("save %d numbers of news collected before %v as partial run %s\n", len(news), ctx.Err(), run.FilePrefix())
+	}
+
+	store.WriteLists(news, listPath, run.FilePrefix(), listOutputFormat)
 
-	switch listOutputFormat {
-	case listTypeTsv:
-		dumpToFile(tableRows, listPath, toFilePrefix(started), "tsv", '\t', false)
-	case listTypeMD:
-		dumpToFile(tableRows, listPath, toFilePrefix(started), "md", '|', true)
-	case listTypeBoth:
-		dumpToFile(tableRows, listPath, toFilePrefix(started), "tsv", '\t', false)
-		dumpToFile(tableRows, listPath, toFilePrefix(started), "md", '|', true)
+	if err = store.WriteJsonGzip(news, run.GzipDumpFile()); err != nil {
+		return err
 	}
 
-	byteArr, errGzip := toJsonGzipBytes(news)
-	if errGzip != nil {
-		return errGzip
+	if err = headline.Track(filepath.Join(rootPath, headline.Dir), news, run.Started); err != nil {
+		log.Println("failed to track headlines of run", run.FilePrefix(), "for", err)
 	}
 
-	if err = ioutil.WriteFile(gzipDumpFile, byteArr, os.FileMode(0644)); err != nil {
-		return err
+	if revisitPeriod > 0 && ctx.Err() == nil {
+		if err = queueRevisits(rootPath, news, nowInLocalZone()); err != nil {
+			log.Println("failed to queue news ends to revisit for", err)
+		}
 	}
 
 	log.Println("collected news", collectSource, collectType, "to", collectDirectoryPath)
 
-	return nil
+	return ctx.Err()
 }
 
-func toJsonGzipBytes(news []types.News) ([]byte, error) {
-	jsonBytes, errJson := json.Marshal(news)
-	if errJson != nil {
-		return nil, errJson
-	}
+// collectorOption is the option of a collector dumping under savePath with the chrome profile in userDataDir.
+func collectorOption(savePath, userDataDir string, endCache cache.Cache) coll.Option {
+	option := coll.Option{
+		SavePath:    savePath,
+		Headless:    !disableHeadless,
+		Logging:     enableChromeLogging,
+		LogLevel:    chromeLoggingVerbosity,
+		UserDataDir: userDataDir,
+		EndCache:    endCache,
+		EndCacheTTL: endCacheTTLs.Of(collectSource),
 
-	buffer := &bytes.Buffer{}
-	gz, errGzip := gzip.NewWriterLevel(buffer, gzip.BestCompression)
-	if errGzip != nil {
-		return nil, errGzip
+		SelectorPackDir: selectorPackDir,
+		Comments:        types.CommentOption{Limit: commentLimit, Sort: commentSort},
 	}
 
-	_, errGzip = gz.Write(jsonBytes)
-	if errGzip != nil {
-		return nil, errGzip
+	if chromeBin != "" {
+		option.ChromeBin = chromeBin
 	}
 
-	if errGzip = gz.Close(); errGzip != nil {
-		return nil, errGzip
+	if recordDirectoryPath != "" {
+		option.RecordDir = filepath.Join(recordDirectoryPath, collectSource, collectType)
 	}
 
-	return buffer.Bytes(), nil
-}
+	if replayDirectoryPath != "" {
+		option.ReplayDir = filepath.Join(replayDirectoryPath, collectSource, collectType)
+	}
 
-func toFilePrefix(t time.Time) string {
-	return fmt.Sprintf("%s-%s", t.Format(types.FileDateFormat), t.Format(types.FileTimeFormat))
+	return option
 }
 
-func toTable(news []types.News) [][]string {
-	tableRows := make([][]string, 0)
-	for idx, n := range news {
-		emotions := make([]string, 0)
-		author := ""
-		publisher := n.Publisher
-		numComment := uint64(0)
-		title := strings.TrimSpace(n.Title)
-		location := n.Location
-		collectedAt := ""
-		postedAt := ""
-		modifiedAt := ""
-		category := ""
-
-		if n.End != nil {
-			author = n.End.Author
-			publisher = n.End.Provider
-			numComment = n.End.NumComment
-			category = n.End.Category
-			collectedAt = n.End.CollectedAt
-			postedAt = n.End.PostedAt
-			modifiedAt = n.End.ModifiedAt
-
-			for _, e := range n.End.Emotions {
-				if e.CountString == "" {
-					emotions = append(emotions, fmt.Sprintf("%s(%d)", e.Name, e.Count))
-				} else {
-					emotions = append(emotions, fmt.Sprintf("%s(%s)", e.Name, e.CountString))
-				}
-			}
-		}
+// newEndCache opens the end cache of --cache, which keeps ends for the ttl of collectSource whoever sets them.
+func newEndCache(savePath string) (cache.Cache, error) {
+	rawURL := endCacheURL
+	if rawURL == "" {
+		rawURL = "file://" + filepath.Join(savePath, "cache")
+	}
 
-		if author == "" {
-			author = "-"
-		}
+	c, err := cache.Open(rawURL)
+	if err != nil {
+		return nil, err
+	}
 
-		if publisher == "" {
-			publisher = "-"
-		}
+	ttl := endCacheTTLs.Of(collectSource)
+	log.Println("keep ends of", collectSource, "in", rawURL, "for", ttl)
 
-		if category == "" {
-			category = "-"
-		}
+	return cache.WithMetrics(cache.WithTTL(c, ttl), collectSource, collectType), nil
+}
 
-		if postedAt == "" {
-			postedAt = "-"
+// cleanup closes the browser even if it is not responding, so the next collection starts a new one.
+func cleanup(c types.Collector) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Println("failed to cleanup collector for", util.PanicAsError(r))
 		}
+	}()
 
-		if modifiedAt == "" {
-			modifiedAt = "-"
-		}
+	c.Cleanup()
+}
 
-		author = strings.TrimSpace(author)
-		publisher = strings.TrimSpace(publisher)
-
-		row := []string{
-			fmt.Sprintf("%d", idx),
-			fmt.Sprintf("%d", numComment),
-			author,
-			publisher,
-			category,
-			title,
-			string(location),
-			collectedAt,
-			postedAt,
-			modifiedAt,
-			emotionsToString(emotions),
-			n.URL,
-		}
+// retryBackoff doubles the wait before retrying for every failure in a row up to retryBackoffMax.
+func retryBackoff(failures int) time.Duration {
+	backoff := retryBackoffInitial
+	for i := 1; i < failures && backoff < retryBackoffMax; i++ {
+		backoff *= 2
+	}
 
-		tableRows = append(tableRows, row)
+	if backoff > retryBackoffMax {
+		backoff = retryBackoffMax
 	}
-	return tableRows
+
+	return backoff
 }
 
 func nowInLocalZone() time.Time {
 	return time.Now().In(time.Local)
 }
 
-func emotionsToString(emotions []string) string {
-	if len(emotions) == 0 {
-		return "-"
-	}
-	return fmt.Sprintf("%v", emotions)
-}
-
 func checkDirWritable(dir string) error {
 	for {
 		s, errStat := os.Stat(dir)
@@ -480,37 +560,6 @@ func checkDirWritable(dir string) error {
 	return nil
 }
 
-func dumpToFile(rows [][]string, listPath, filePrefix, ext string, sep rune, headerLine bool) {
-	buffer := &bytes.Buffer{}
-	table := csv.NewWriter(buffer)
-	table.Comma = sep
-
-	if sep == '|' {
-		for i := range rows {
-			for j := range rows[i] {
-				rows[i][j] = strings.ReplaceAll(rows[i][j], "|", "&vert;")
-			}
-		}
-	}
-
-	_ = table.Write(listHeader)
-	if headerLine {
-		_ = table.Write(listHeaderLine)
-	}
-	_ = table.WriteAll(rows)
-	table.Flush()
-
-	outputFile := filepath.Join(listPath, fmt.Sprintf("%s.%s", filePrefix, ext))
-
-	if err := table.Error(); err != nil {
-		log.Printf("error occured when writing list of type %s %v", ext, err)
-	}
-
-	if err := ioutil.WriteFile(outputFile, buffer.Bytes(), os.FileMode(0600)); err != nil {
-		log.Println("failed to write to", outputFile, "for", err.Error())
-	}
-}
-
 func validateFlags() error {
 	switch collectType {
 	case coll.PC, coll.Mobile:
@@ -528,10 +577,65 @@ func validateFlags() error {
 		return err
 	}
 
-	if _, ok := availableListTypes[listOutputFormat]; false == ok {
+	if _, ok := store.AvailableListTypes[listOutputFormat]; false == ok {
 		return fmt.Errorf("list output type %s is not supported", listOutputFormat)
 	}
 
+	if maxConsecutiveFailures < 1 {
+		return fmt.Errorf("max-consecutive-failures should be greater than 0. not %d", maxConsecutiveFailures)
+	}
+
+	if retryBackoffInitial <= 0 || retryBackoffMax < retryBackoffInitial {
+		return fmt.Errorf("retry-backoff %s should be positive and not greater than retry-backoff-max %s", retryBackoffInitial, retryBackoffMax)
+	}
+
+	if readyPeriods < 1 {
+		return fmt.Errorf("ready-periods should be greater than 0. not %d", readyPeriods)
+	}
+
+	ttls, err := cache.ParseTTLs(endCacheTTL)
+	if err != nil {
+		return fmt.Errorf("end-cache-ttl %s is invalid: %v", endCacheTTL, err)
+	}
+	for source := range ttls.Sources {
+		switch source {
+		case coll.Daum, coll.Naver:
+		default:
+			return fmt.Errorf("source of end-cache-ttl should be %s. not %s", coll.Sources, source)
+		}
+	}
+	endCacheTTLs = ttls
+
+	if endConcurrency < 1 {
+		return fmt.Errorf("end-concurrency should be greater than 0. not %d", endConcurrency)
+	}
+
+	if revisitPeriod < 0 || (revisitPeriod > 0 && revisitWindow < revisitPeriod) {
+		return fmt.Errorf("revisit-period %s should not be negative and not greater than revisit-window %s", revisitPeriod, revisitWindow)
+	}
+
+	if revisitPeriod > 0 && revisitPeriod < collectPeriod {
+		return fmt.Errorf("revisit-period %s should not be less than collect-period %s", revisitPeriod, collectPeriod)
+	}
+
+	if revisitLimit < 1 {
+		return fmt.Errorf("revisit-limit should be greater than 0. not %d", revisitLimit)
+	}
+
+	if commentLimit < 0 {
+		return fmt.Errorf("comments should not be negative. not %d", commentLimit)
+	}
+
+	switch commentSort {
+	case types.CommentSortRecommended, types.CommentSortLatest:
+	default:
+		return fmt.Errorf("comment-sort should be %s or %s. not %s", types.CommentSortRecommended, types.CommentSortLatest, commentSort)
+	}
+
+	if recordDirectoryPath != "" && replayDirectoryPath != "" {
+		return fmt.Errorf("record and replay can not be used together")
+	}
+
 	return nil
 }
 
@@ -0,0 +1,48 @@
+package coll
+
+import (
+	"log"
+	"path/filepath"
+
+	"github.com/darimuri/coll-news/pkg/drift"
+	"github.com/darimuri/coll-news/pkg/metrics"
+	"github.com/darimuri/coll-news/pkg/store"
+)
+
+// checkDrift compares what rec recorded in run with the baseline of recent runs under savePath and
+// reports drifts with html samples to <savePath>/drift. Only succeeded runs roll into the baseline,
+// while a failed run is still checked since a changed layout is a common cause of the failure.
+func checkDrift(savePath string, run store.Run, rec *drift.Recorder, m *metrics.Collection, succeeded bool) {
+	dir := filepath.Join(savePath, "drift")
+	baselineFile := filepath.Join(dir, drift.BaselineFile)
+
+	baseline, err := drift.LoadBaseline(baselineFile)
+	if err != nil {
+		log.Println("skip layout drift check for", err)
+		return
+	}
+
+	recorded := rec.Run()
+	m.Matched(recorded)
+
+	drifts := baseline.Check(recorded)
+	if len(drifts) > 0 {
+		for _, d := range drifts {
+			log.Println("WARNING layout drift of run", run.FilePrefix(), d.String())
+		}
+		m.Drifted(drifts)
+
+		if err = drift.WriteReport(dir, run.FilePrefix(), drifts, rec); err != nil {
+			log.Println("failed to write layout drift report for", err)
+		}
+	}
+
+	if false == succeeded {
+		return
+	}
+
+	baseline.Add(recorded)
+	if err = baseline.Save(baselineFile); err != nil {
+		log.Println("failed to save layout drift baseline for", err)
+	}
+}
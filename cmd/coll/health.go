@@ -0,0 +1,126 @@
+package coll
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo"
+
+	"github.com/darimuri/coll-news/pkg/types"
+)
+
+const (
+	statusOK    = "ok"
+	statusError = "error"
+)
+
+type healthStatus struct {
+	Status        string `json:"status"`
+	Message       string `json:"message,omitempty"`
+	Collecting    bool   `json:"collecting"`
+	LastSucceeded string `json:"last_succeeded,omitempty"`
+	LastError     string `json:"last_error,omitempty"`
+	LastErrorAt   string `json:"last_error_at,omitempty"`
+}
+
+// health keeps the state of collections for /healthz and /readyz of the metrics server.
+type health struct {
+	mu sync.Mutex
+
+	collector     types.Collector
+	lastSucceeded time.Time
+	lastError     error
+	lastErrorAt   time.Time
+}
+
+var status = &health{}
+
+func (h *health) begin(c types.Collector) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.collector = c
+}
+
+// release forgets the collector of the run before it is cleaned up, so its closed browser is not asked if alive.
+func (h *health) release() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.collector = nil
+}
+
+func (h *health) end(err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.collector = nil
+
+	if err != nil {
+		h.lastError = err
+		h.lastErrorAt = time.Now()
+	} else {
+		h.lastSucceeded = time.Now()
+	}
+}
+
+func (h *health) status() (healthStatus, types.Collector, time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s := healthStatus{Status: statusOK, Collecting: h.collector != nil}
+	if false == h.lastSucceeded.IsZero() {
+		s.LastSucceeded = h.lastSucceeded.Format(types.DataDateTimeFormat)
+	}
+	if h.lastError != nil {
+		s.LastError = h.lastError.Error()
+		s.LastErrorAt = h.lastErrorAt.Format(types.DataDateTimeFormat)
+	}
+
+	return s, h.collector, h.lastSucceeded
+}
+
+// healthz fails when chrome of the running collection does not answer. Between collections there is no
+// browser, so only the process is alive.
+func (h *health) healthz(c echo.Context) error {
+	s, collector, _ := h.status()
+
+	if collector != nil {
+		if err := collector.Alive(); err != nil {
+			s.Status = statusError
+			s.Message = fmt.Sprintf("chrome is not alive for %v", err)
+			return c.JSON(http.StatusServiceUnavailable, s)
+		}
+	}
+
+	return c.JSON(http.StatusOK, s)
+}
+
+// readyz fails when no collection succeeded within readyPeriods of collect period or the dump directory
+// is not writable.
+func (h *health) readyz(savePath string) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		s, _, lastSucceeded := h.status()
+
+		deadline := collectPeriod * time.Duration(readyPeriods)
+
+		if lastSucceeded.IsZero() {
+			s.Status = statusError
+			s.Message = "no collection is succeeded yet"
+		} else if time.Since(lastSucceeded) > deadline {
+			s.Status = statusError
+			s.Message = fmt.Sprintf("no collection is succeeded for %s", deadline)
+		} else if err := checkDirWritable(savePath); err != nil {
+			s.Status = statusError
+			s.Message = err.Error()
+		}
+
+		if s.Status != statusOK {
+			return c.JSON(http.StatusServiceUnavailable, s)
+		}
+
+		return c.JSON(http.StatusOK, s)
+	}
+}
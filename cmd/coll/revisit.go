@@ -0,0 +1,163 @@
+package coll
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/darimuri/coll-news/pkg/adaptor"
+	"github.com/darimuri/coll-news/pkg/cache"
+	"github.com/darimuri/coll-news/pkg/coll"
+	"github.com/darimuri/coll-news/pkg/metrics"
+	"github.com/darimuri/coll-news/pkg/revisit"
+	"github.com/darimuri/coll-news/pkg/types"
+)
+
+// revisitMu guards the queue file, which collections add news to while revisits mark them visited.
+var revisitMu sync.Mutex
+
+// queueRevisits queues news of a run under <savePath>/revisit with the first sample of the ones seen first.
+func queueRevisits(savePath string, news []types.News, now time.Time) error {
+	dir := filepath.Join(savePath, revisit.Dir)
+
+	revisitMu.Lock()
+	defer revisitMu.Unlock()
+
+	q, err := revisit.Load(filepath.Join(dir, revisit.QueueFile))
+	if err != nil {
+		return err
+	}
+
+	for _, n := range q.Add(news, now) {
+		if err = revisit.Append(filepath.Join(dir, revisit.SeriesDir), revisit.NewSample(n, now)); err != nil {
+			return err
+		}
+	}
+
+	return q.Save(filepath.Join(dir, revisit.QueueFile))
+}
+
+// revisitEvery revisits ends due every revisitPeriod apart from collections until ctx is done. A round collects
+// at most revisitLimit ends and stops after revisitPeriod, so a long queue never runs into the next round.
+func revisitEvery(ctx context.Context, savePath string, endCache cache.Cache, m *metrics.Collection) {
+	ticker := time.NewTicker(revisitPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			started := time.Now()
+
+			roundCtx, cancel := context.WithTimeout(ctx, revisitPeriod)
+			if err := revisitEnds(roundCtx, savePath, endCache); err != nil {
+				log.Println("failed to revisit news ends for", err)
+			}
+			cancel()
+
+			m.ObservePhase(metrics.PhaseRevisit, started)
+		}
+	}
+}
+
+// revisitEnds collects ends of articles due again with a browser of its own, so each article seen within
+// revisitWindow gets an engagement sample every revisitPeriod in its time series. Articles not reached before
+// ctx is done stay due for the next round.
+func revisitEnds(ctx context.Context, savePath string, endCache cache.Cache) (retErr error) {
+	dir := filepath.Join(savePath, revisit.Dir)
+	seriesDir := filepath.Join(dir, revisit.SeriesDir)
+	queueFile := filepath.Join(dir, revisit.QueueFile)
+
+	var due []revisit.Entry
+
+	revisitMu.Lock()
+	q, err := revisit.Load(queueFile)
+	if err == nil {
+		due = q.Due(nowInLocalZone(), revisitWindow, revisitPeriod)
+		// saved without the articles out of the window Due dropped
+		err = q.Save(queueFile)
+	}
+	revisitMu.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	if len(due) == 0 {
+		return nil
+	}
+
+	if len(due) > revisitLimit {
+		log.Printf("revisit %d of %d numbers of news ends due, the rest are left to the next round\n", revisitLimit, len(due))
+		due = due[:revisitLimit]
+	} else {
+		log.Printf("revisit %d numbers of news ends seen within %s\n", len(due), revisitWindow)
+	}
+
+	c, err := coll.NewCollector(collectSource, collectType, collectorOption(dir, filepath.Join(userDataRoot, collectSource, collectType+"-revisit"), endCache))
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			switch v := r.(type) {
+			case error:
+				retErr = v
+			default:
+				retErr = fmt.Errorf("unknown panic cause %+v", v)
+			}
+		}
+
+		cleanup(c)
+	}()
+
+	dueNews := make([]types.News, len(due))
+	for i, e := range due {
+		dueNews[i] = types.News{URL: e.URL, Title: e.Title}
+	}
+
+	errs := c.GetNewsEnds(adaptor.WithRefresh(ctx), dueNews, endConcurrency, false)
+	if keepVersions {
+		if err = recordVersions(savePath, dueNews); err != nil {
+			log.Println("failed to record versions of revisited news ends for", err)
+		}
+	}
+
+	visited := make([]string, 0, len(dueNews))
+	for i, n := range dueNews {
+		if n.End == nil && ctx.Err() != nil {
+			continue
+		}
+
+		visited = append(visited, n.URL)
+
+		if errs[i] != nil || n.End == nil {
+			log.Println("skip engagement sample of", n.URL, "for", errs[i])
+			continue
+		}
+
+		if err = revisit.Append(seriesDir, revisit.NewSample(n, time.Now())); err != nil {
+			return err
+		}
+	}
+
+	revisitMu.Lock()
+	defer revisitMu.Unlock()
+
+	// collections may have queued news meanwhile, so the queue is read again
+	if q, err = revisit.Load(queueFile); err != nil {
+		return err
+	}
+
+	now := nowInLocalZone()
+	for _, url := range visited {
+		q.Visited(url, now)
+	}
+
+	return q.Save(queueFile)
+}
@@ -0,0 +1,35 @@
+package coll
+
+import (
+	"log"
+	"path/filepath"
+	"sync"
+
+	"github.com/darimuri/coll-news/pkg/history"
+	"github.com/darimuri/coll-news/pkg/types"
+)
+
+// versionsMu keeps collections and revisits from numbering a version of the same article at once.
+var versionsMu sync.Mutex
+
+// recordVersions keeps ends of news under <savePath>/versions, a new version with diffs whenever an article
+// is collected again with a different modified time, title or text.
+func recordVersions(savePath string, news []types.News) error {
+	dir := filepath.Join(savePath, history.Dir)
+
+	versionsMu.Lock()
+	defer versionsMu.Unlock()
+
+	for _, n := range news {
+		v, err := history.Record(dir, n)
+		if err != nil {
+			return err
+		}
+
+		if v != nil && v.Number > 1 {
+			log.Printf("news end %s is edited to version %d\n", n.URL, v.Number)
+		}
+	}
+
+	return nil
+}
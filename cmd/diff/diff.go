@@ -0,0 +1,132 @@
+package diff
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/darimuri/coll-news/pkg/coll"
+	"github.com/darimuri/coll-news/pkg/snapshot"
+	"github.com/darimuri/coll-news/pkg/store"
+)
+
+const (
+	gzipDumpSuffix = ".json.gz"
+	partialSuffix  = ".partial"
+)
+
+var (
+	collectType          string
+	collectSource        string
+	collectDirectoryPath string
+	outputFormat         string
+)
+
+var Command = &cobra.Command{
+	Use:   "diff <run-a> <run-b>",
+	Short: "Report news added, removed, moved and retitled from a collection to another",
+	Long: "Report news added, removed, moved and retitled from a collection to another.\n" +
+		"runs are json.gz dump files, or file prefixes(e.g. 20211016-040021) of collections of the source and type under the save path",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return diff(args[0], args[1])
+	},
+
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := cobra.ExactArgs(2)(cmd, args); err != nil {
+			return err
+		}
+
+		return validateFlags()
+	},
+}
+
+func init() {
+	Command.Flags().StringVarP(&collectType, "collect-type", "t", "", fmt.Sprintf("collect news type(%s) of runs given by file prefix", coll.Types))
+	Command.Flags().StringVarP(&collectSource, "collect-news-source", "s", "", fmt.Sprintf("news source(%s) of runs given by file prefix", coll.Sources))
+	Command.Flags().StringVarP(&collectDirectoryPath, "save-directory-path", "d", "", "save path of collected data of runs given by file prefix")
+	Command.Flags().StringVarP(&outputFormat, "output", "o", snapshot.FormatText, fmt.Sprintf("output format(%s)", snapshot.FormatsDesc))
+
+	log.SetFlags(log.LstdFlags | log.Lmicroseconds | log.Lshortfile)
+}
+
+func diff(runA, runB string) error {
+	fileA, err := dumpFile(runA)
+	if err != nil {
+		return err
+	}
+
+	fileB, err := dumpFile(runB)
+	if err != nil {
+		return err
+	}
+
+	newsA, err := store.ReadJsonGzip(fileA)
+	if err != nil {
+		return err
+	}
+
+	newsB, err := store.ReadJsonGzip(fileB)
+	if err != nil {
+		return err
+	}
+
+	out, err := snapshot.Format(snapshot.Compare(runA, newsA, runB, newsB), outputFormat)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(out)
+
+	return nil
+}
+
+// dumpFile finds the json.gz dump of a run given by its file or its file prefix.
+func dumpFile(run string) (string, error) {
+	if strings.HasSuffix(run, gzipDumpSuffix) {
+		return run, nil
+	}
+
+	if collectDirectoryPath == "" || collectSource == "" || collectType == "" {
+		return "", fmt.Errorf("run %s is not a json.gz dump, so save-directory-path, collect-news-source and collect-type are required", run)
+	}
+
+	rootPath := filepath.Join(collectDirectoryPath, collectSource, collectType)
+
+	r, err := store.ParseRun(rootPath, strings.TrimSuffix(run, partialSuffix))
+	if err != nil {
+		return "", err
+	}
+	r.Partial = strings.HasSuffix(run, partialSuffix)
+
+	if _, err = os.Stat(r.GzipDumpFile()); err != nil {
+		return "", err
+	}
+
+	return r.GzipDumpFile(), nil
+}
+
+func validateFlags() error {
+	switch collectType {
+	case "", coll.PC, coll.Mobile:
+	default:
+		return fmt.Errorf("type should be %s. not %s", coll.Types, collectType)
+	}
+
+	switch collectSource {
+	case "", coll.Daum, coll.Naver:
+	default:
+		return fmt.Errorf("news-source should be %s. not %s", coll.Sources, collectSource)
+	}
+
+	switch outputFormat {
+	case snapshot.FormatText, snapshot.FormatMD, snapshot.FormatJSON:
+	default:
+		return fmt.Errorf("output should be %s. not %s", snapshot.FormatsDesc, outputFormat)
+	}
+
+	return nil
+}
@@ -6,7 +6,12 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/darimuri/coll-news/cmd/cluster"
 	"github.com/darimuri/coll-news/cmd/coll"
+	"github.com/darimuri/coll-news/cmd/diff"
+	"github.com/darimuri/coll-news/cmd/reparse"
+	"github.com/darimuri/coll-news/cmd/timeline"
+	"github.com/darimuri/coll-news/cmd/titles"
 	"github.com/darimuri/coll-news/cmd/version"
 )
 
@@ -20,7 +25,7 @@ var rootCmd = &cobra.Command{
 }
 
 func main() {
-	rootCmd.AddCommand(coll.Command, version.Command)
+	rootCmd.AddCommand(cluster.Command, coll.Command, diff.Command, reparse.Command, timeline.Command, titles.Command, version.Command)
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
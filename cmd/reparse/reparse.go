@@ -0,0 +1,326 @@
+package reparse
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/darimuri/coll-news/pkg/coll"
+	"github.com/darimuri/coll-news/pkg/store"
+	"github.com/darimuri/coll-news/pkg/types"
+	"github.com/darimuri/coll-news/pkg/util"
+)
+
+const (
+	htmlDumpSuffix = ".00.html"
+	gzipDumpSuffix = ".json.gz"
+	topDumpSource  = "top"
+	homeDumpSource = "news"
+)
+
+var (
+	collectType          string
+	collectSource        string
+	collectDirectoryPath string
+	listOutputFormat     string
+	chromeBin            string
+	runPrefix            string
+	topHTML              string
+	homeHTML             string
+	selectorPackDir      string
+	disableHeadless      bool
+)
+
+var Command = &cobra.Command{
+	Use:   "reparse",
+	Short: "Rebuild news lists of a collection from its saved html dumps",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return reparse()
+	},
+
+	Args: func(cmd *cobra.Command, args []string) error {
+		return validateFlags()
+	},
+}
+
+func init() {
+	Command.Flags().StringVarP(&chromeBin, "chrome-bin", "b", "", "chrome browser binary path")
+	Command.Flags().StringVarP(&collectType, "collect-type", "t", "", fmt.Sprintf("collect news type(%s)", coll.Types))
+	Command.Flags().StringVarP(&collectSource, "collect-news-source", "s", "", fmt.Sprintf("news source(%s)", coll.Sources))
+	Command.Flags().StringVarP(&collectDirectoryPath, "save-directory-path", "d", "", "save path of collected data")
+	Command.Flags().StringVarP(&listOutputFormat, "list-output-format", "f", "b", fmt.Sprintf("list output format of collected news(%s)", store.ListTypesDesc))
+	Command.Flags().StringVarP(&runPrefix, "run", "r", "", "file prefix of the collection to rebuild(e.g. 20211016-040021)")
+	Command.Flags().StringVarP(&topHTML, "top-html", "", "", "top html dump to reparse instead of the one found for the run")
+	Command.Flags().StringVarP(&homeHTML, "home-html", "", "", "news home html dump to reparse instead of the one found for the run")
+	Command.Flags().StringVarP(&selectorPackDir, "selector-pack-dir", "", "", "directory of selector packs(e.g. daum-pc.yaml) used instead of the built-in ones")
+	Command.Flags().BoolVarP(&disableHeadless, "no-headless", "n", false, "reparse news in non-headless mode")
+
+	//goland:noinspection GoUnhandledErrorResult
+	Command.MarkFlagRequired("collect-type")
+	//goland:noinspection GoUnhandledErrorResult
+	Command.MarkFlagRequired("collect-news-source")
+	//goland:noinspection GoUnhandledErrorResult
+	Command.MarkFlagRequired("save-directory-path")
+	//goland:noinspection GoUnhandledErrorResult
+	Command.MarkFlagRequired("run")
+
+	log.SetFlags(log.LstdFlags | log.Lmicroseconds | log.Lshortfile)
+}
+
+func reparse() error {
+	rootPath := filepath.Join(collectDirectoryPath, collectSource, collectType)
+
+	run, err := store.ParseRun(rootPath, runPrefix)
+	if err != nil {
+		return err
+	}
+
+	windowEnd, err := nextRunStarted(run)
+	if err != nil {
+		return err
+	}
+
+	if topHTML == "" {
+		if topHTML, err = findHTMLDump(run, windowEnd, topDumpSource); err != nil {
+			return err
+		}
+	}
+
+	if homeHTML == "" {
+		if homeHTML, err = findHTMLDump(run, windowEnd, homeDumpSource); err != nil {
+			log.Println("news home will not be reparsed for", err)
+		}
+	}
+
+	option := coll.Option{
+		SavePath:    run.DumpPath(),
+		Headless:    !disableHeadless,
+		UserDataDir: filepath.Join("/tmp/rod/reparse", collectSource, collectType),
+
+		SelectorPackDir: selectorPackDir,
+	}
+
+	if chromeBin != "" {
+		option.ChromeBin = chromeBin
+	}
+
+	a, err := coll.NewAdaptor(collectSource, collectType, option)
+	if err != nil {
+		return err
+	}
+	defer a.Cleanup()
+
+	log.Println("reparse top news list from", topHTML)
+
+	news, err := a.Reparse(context.Background(), types.Top, topHTML)
+	if err != nil {
+		return err
+	}
+
+	if homeHTML != "" {
+		log.Println("reparse news home news list from", homeHTML)
+
+		homeNews, errHome := a.Reparse(context.Background(), types.Home, homeHTML)
+		if errHome != nil {
+			return errHome
+		}
+
+		news = append(news, homeNews...)
+	}
+
+	previous, err := readPrevious(run)
+	if err != nil {
+		return err
+	}
+
+	numMissingEnd := mergePrevious(news, previous)
+	if numMissingEnd > 0 {
+		log.Printf("%d numbers of reparsed news have no end in the previous dump\n", numMissingEnd)
+	}
+
+	if err = os.MkdirAll(run.ListPath(), os.FileMode(0700)); err != nil {
+		return err
+	}
+
+	store.WriteLists(news, run.ListPath(), run.FilePrefix(), listOutputFormat)
+
+	if err = store.WriteJsonGzip(news, run.GzipDumpFile()); err != nil {
+		return err
+	}
+
+	log.Printf("rebuilt %d numbers of news for run %s to %s\n", len(news), runPrefix, rootPath)
+
+	return nil
+}
+
+// readPrevious loads the dump written while collecting and keeps it aside before it is overwritten.
+func readPrevious(run store.Run) ([]types.News, error) {
+	gzipDumpFile := run.GzipDumpFile()
+	if _, errStat := os.Stat(gzipDumpFile); os.IsNotExist(errStat) {
+		return []types.News{}, nil
+	}
+
+	previous, err := store.ReadJsonGzip(gzipDumpFile)
+	if err != nil {
+		return nil, err
+	}
+
+	backupFile := gzipDumpFile + ".orig"
+	if _, errStat := os.Stat(backupFile); os.IsNotExist(errStat) {
+		if err = os.Rename(gzipDumpFile, backupFile); err != nil {
+			return nil, err
+		}
+		log.Println("previous dump is kept as", backupFile)
+	}
+
+	return previous, nil
+}
+
+// mergePrevious fills ends and collected time of reparsed news from the previous dump of the same run.
+func mergePrevious(news []types.News, previous []types.News) int {
+	ends := make(map[string]*types.End)
+	collectedAt := make(map[types.Loc]string)
+
+	for _, p := range previous {
+		if p.CollectedAt != "" {
+			collectedAt[p.Location] = p.CollectedAt
+		}
+
+		if p.End == nil {
+			continue
+		}
+
+		if key, err := util.NormalizeURL(p.URL); err == nil {
+			ends[key] = p.End
+		}
+	}
+
+	numMissingEnd := 0
+	for i := range news {
+		news[i].CollectedAt = collectedAt[news[i].Location]
+
+		key, err := util.NormalizeURL(news[i].URL)
+		if err != nil {
+			numMissingEnd++
+			continue
+		}
+
+		if end, ok := ends[key]; ok {
+			news[i].End = end
+		} else {
+			numMissingEnd++
+		}
+	}
+
+	return numMissingEnd
+}
+
+// nextRunStarted finds the start of the collection after run, which closes the window of its html dumps.
+func nextRunStarted(run store.Run) (time.Time, error) {
+	windowEnd := run.Started.Add(time.Hour)
+
+	files, err := ioutil.ReadDir(run.FullDumpPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return windowEnd, nil
+		}
+		return windowEnd, err
+	}
+
+	for _, f := range files {
+		if false == strings.HasSuffix(f.Name(), gzipDumpSuffix) {
+			continue
+		}
+
+		next, errParse := store.ParseRun(run.RootPath, strings.TrimSuffix(f.Name(), gzipDumpSuffix))
+		if errParse != nil {
+			continue
+		}
+
+		if next.Started.After(run.Started) && next.Started.Before(windowEnd) {
+			windowEnd = next.Started
+		}
+	}
+
+	return windowEnd, nil
+}
+
+// findHTMLDump returns the last html dump of source taken between the run start and windowEnd.
+// Failed list retries leave earlier dumps behind, so the last one is the one the list was made of.
+func findHTMLDump(run store.Run, windowEnd time.Time, source string) (string, error) {
+	candidates := make([]string, 0)
+
+	days := []time.Time{run.Started}
+	if windowEnd.Format(types.FileDateFormat) != run.Started.Format(types.FileDateFormat) {
+		days = append(days, windowEnd)
+	}
+
+	for _, day := range days {
+		dir := filepath.Join(run.DumpPath(), day.Format(types.FileDateFormat), source)
+
+		files, err := ioutil.ReadDir(dir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return "", err
+		}
+
+		for _, f := range files {
+			if false == strings.HasSuffix(f.Name(), htmlDumpSuffix) {
+				continue
+			}
+
+			dumpTime, errParse := time.ParseInLocation(
+				types.FileDateFormat+types.FileTimeNanoFormat,
+				day.Format(types.FileDateFormat)+strings.TrimSuffix(f.Name(), htmlDumpSuffix),
+				time.Local,
+			)
+			if errParse != nil {
+				continue
+			}
+
+			if dumpTime.Before(run.Started) || false == dumpTime.Before(windowEnd) {
+				continue
+			}
+
+			candidates = append(candidates, filepath.Join(dir, f.Name()))
+		}
+	}
+
+	if len(candidates) == 0 {
+		return "", fmt.Errorf("no %s html dump is found for run %s", source, run.FilePrefix())
+	}
+
+	sort.Strings(candidates)
+
+	return candidates[len(candidates)-1], nil
+}
+
+func validateFlags() error {
+	switch collectType {
+	case coll.PC, coll.Mobile:
+	default:
+		return fmt.Errorf("type should be %s. not %s", coll.Types, collectType)
+	}
+
+	switch collectSource {
+	case coll.Daum, coll.Naver:
+	default:
+		return fmt.Errorf("news-source should be %s. not %s", coll.Sources, collectSource)
+	}
+
+	if _, ok := store.AvailableListTypes[listOutputFormat]; false == ok {
+		return fmt.Errorf("list output type %s is not supported", listOutputFormat)
+	}
+
+	return nil
+}
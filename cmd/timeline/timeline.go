@@ -0,0 +1,143 @@
+package timeline
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/darimuri/coll-news/pkg/coll"
+	"github.com/darimuri/coll-news/pkg/snapshot"
+	"github.com/darimuri/coll-news/pkg/store"
+	"github.com/darimuri/coll-news/pkg/types"
+)
+
+var (
+	collectType          string
+	collectSource        string
+	collectDirectoryPath string
+	fromDate             string
+	toDate               string
+	outputFormat         string
+)
+
+var Command = &cobra.Command{
+	Use:   "timeline",
+	Short: "Report when articles were listed, how long they stayed and where, from dumps of a date range",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return timeline()
+	},
+
+	Args: func(cmd *cobra.Command, args []string) error {
+		return validateFlags()
+	},
+}
+
+func init() {
+	Command.Flags().StringVarP(&collectType, "collect-type", "t", "", fmt.Sprintf("collect news type(%s)", coll.Types))
+	Command.Flags().StringVarP(&collectSource, "collect-news-source", "s", "", fmt.Sprintf("news source(%s)", coll.Sources))
+	Command.Flags().StringVarP(&collectDirectoryPath, "save-directory-path", "d", "", "save path of collected data")
+	Command.Flags().StringVarP(&fromDate, "from", "", "", "first date of runs(e.g. 20211016)")
+	Command.Flags().StringVarP(&toDate, "to", "", "", "last date of runs(e.g. 20211017, default is the first date)")
+	Command.Flags().StringVarP(&outputFormat, "output", "o", snapshot.FormatText, fmt.Sprintf("output format(%s)", snapshot.FormatsDesc))
+
+	//goland:noinspection GoUnhandledErrorResult
+	Command.MarkFlagRequired("collect-type")
+	//goland:noinspection GoUnhandledErrorResult
+	Command.MarkFlagRequired("collect-news-source")
+	//goland:noinspection GoUnhandledErrorResult
+	Command.MarkFlagRequired("save-directory-path")
+	//goland:noinspection GoUnhandledErrorResult
+	Command.MarkFlagRequired("from")
+
+	log.SetFlags(log.LstdFlags | log.Lmicroseconds | log.Lshortfile)
+}
+
+func timeline() error {
+	rootPath := filepath.Join(collectDirectoryPath, collectSource, collectType)
+
+	if toDate == "" {
+		toDate = fromDate
+	}
+
+	runs, err := store.Runs(rootPath)
+	if err != nil {
+		return err
+	}
+
+	tl := snapshot.NewTimeline()
+	numRuns := 0
+
+	for _, run := range runs {
+		date := run.Started.Format(types.FileDateFormat)
+		if date < fromDate || date > toDate {
+			continue
+		}
+
+		// a partial run lists only what it collected before it was stopped, which would look like articles left
+		if run.Partial {
+			log.Println("skip partial run", run.FilePrefix())
+			continue
+		}
+
+		news, errRead := store.ReadJsonGzip(run.GzipDumpFile())
+		if errRead != nil {
+			log.Println("skip run", run.FilePrefix(), "for", errRead)
+			continue
+		}
+
+		tl.Add(run.Started, news)
+		numRuns++
+	}
+
+	if numRuns == 0 {
+		return fmt.Errorf("no run is found from %s to %s under %s", fromDate, toDate, rootPath)
+	}
+
+	out, err := snapshot.FormatTimeline(tl, outputFormat)
+	if err != nil {
+		return err
+	}
+
+	fmt.Print(out)
+
+	return nil
+}
+
+func validateFlags() error {
+	switch collectType {
+	case coll.PC, coll.Mobile:
+	default:
+		return fmt.Errorf("type should be %s. not %s", coll.Types, collectType)
+	}
+
+	switch collectSource {
+	case coll.Daum, coll.Naver:
+	default:
+		return fmt.Errorf("news-source should be %s. not %s", coll.Sources, collectSource)
+	}
+
+	for _, date := range []string{fromDate, toDate} {
+		if date == "" {
+			continue
+		}
+
+		if _, err := time.Parse(types.FileDateFormat, date); err != nil {
+			return fmt.Errorf("date %s should be formatted as %s: %v", date, types.FileDateFormat, err)
+		}
+	}
+
+	if toDate != "" && toDate < fromDate {
+		return fmt.Errorf("to %s should not be before from %s", toDate, fromDate)
+	}
+
+	switch outputFormat {
+	case snapshot.FormatText, snapshot.FormatMD, snapshot.FormatJSON:
+	default:
+		return fmt.Errorf("output should be %s. not %s", snapshot.FormatsDesc, outputFormat)
+	}
+
+	return nil
+}
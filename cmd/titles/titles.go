@@ -0,0 +1,133 @@
+package titles
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/darimuri/coll-news/pkg/coll"
+	"github.com/darimuri/coll-news/pkg/headline"
+	"github.com/darimuri/coll-news/pkg/store"
+	"github.com/darimuri/coll-news/pkg/types"
+	"github.com/darimuri/coll-news/pkg/util"
+)
+
+var (
+	collectType          string
+	collectSource        string
+	collectDirectoryPath string
+	fromDumps            bool
+)
+
+var Command = &cobra.Command{
+	Use:   "titles <url>",
+	Short: "Print every list and end title an article was seen with across collections",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return titles(args[0])
+	},
+
+	Args: func(cmd *cobra.Command, args []string) error {
+		if err := cobra.ExactArgs(1)(cmd, args); err != nil {
+			return err
+		}
+
+		return validateFlags()
+	},
+}
+
+func init() {
+	Command.Flags().StringVarP(&collectType, "collect-type", "t", "", fmt.Sprintf("collect news type(%s)", coll.Types))
+	Command.Flags().StringVarP(&collectSource, "collect-news-source", "s", "", fmt.Sprintf("news source(%s)", coll.Sources))
+	Command.Flags().StringVarP(&collectDirectoryPath, "save-directory-path", "d", "", "save path of collected data")
+	Command.Flags().BoolVarP(&fromDumps, "from-dumps", "", false, "read titles from every json.gz dump instead of the tracked history, for collections made before titles were tracked")
+
+	//goland:noinspection GoUnhandledErrorResult
+	Command.MarkFlagRequired("collect-type")
+	//goland:noinspection GoUnhandledErrorResult
+	Command.MarkFlagRequired("collect-news-source")
+	//goland:noinspection GoUnhandledErrorResult
+	Command.MarkFlagRequired("save-directory-path")
+
+	log.SetFlags(log.LstdFlags | log.Lmicroseconds | log.Lshortfile)
+}
+
+func titles(url string) error {
+	rootPath := filepath.Join(collectDirectoryPath, collectSource, collectType)
+
+	var h *headline.History
+	var err error
+
+	if fromDumps {
+		h, err = readDumps(rootPath, url)
+	} else {
+		h, err = headline.Load(filepath.Join(rootPath, headline.Dir), url)
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(h.Titles) == 0 {
+		return fmt.Errorf("no title is seen for %s under %s(--from-dumps reads collections made before titles were tracked)", url, rootPath)
+	}
+
+	fmt.Println(h.URL)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	for _, t := range h.Titles {
+		//goland:noinspection GoUnhandledErrorResult
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", t.Kind, t.FirstSeen.Format(types.DataDateTimeFormat), t.LastSeen.Format(types.DataDateTimeFormat), t.Text)
+	}
+
+	return w.Flush()
+}
+
+// readDumps makes the history of url from news of every run dumped under rootPath.
+func readDumps(rootPath, url string) (*headline.History, error) {
+	key, err := util.NormalizeURL(url)
+	if err != nil {
+		return nil, err
+	}
+
+	runs, err := store.Runs(rootPath)
+	if err != nil {
+		return nil, err
+	}
+
+	h := &headline.History{URL: url, Titles: make([]headline.Title, 0)}
+
+	for _, run := range runs {
+		news, errRead := store.ReadJsonGzip(run.GzipDumpFile())
+		if errRead != nil {
+			log.Println("skip run", run.FilePrefix(), "for", errRead)
+			continue
+		}
+
+		for _, n := range news {
+			if nKey, errKey := util.NormalizeURL(n.URL); errKey == nil && nKey == key {
+				h.SeeNews(n, run.Started)
+			}
+		}
+	}
+
+	return h, nil
+}
+
+func validateFlags() error {
+	switch collectType {
+	case coll.PC, coll.Mobile:
+	default:
+		return fmt.Errorf("type should be %s. not %s", coll.Types, collectType)
+	}
+
+	switch collectSource {
+	case coll.Daum, coll.Naver:
+	default:
+		return fmt.Errorf("news-source should be %s. not %s", coll.Sources, collectSource)
+	}
+
+	return nil
+}
@@ -3,11 +3,12 @@ module github.com/darimuri/coll-news
 go 1.13
 
 require (
-	github.com/PraserX/atomic-cache v1.2.1
+	github.com/alicebob/miniredis/v2 v2.30.0
 	github.com/allegro/bigcache v1.2.1 // indirect
 	github.com/coocood/freecache v1.1.1 // indirect
 	github.com/darimuri/go-lib v0.2.2
 	github.com/emirpasic/gods v1.12.0 // indirect
+	github.com/go-redis/redis/v7 v7.4.1
 	github.com/go-rod/rod v0.101.8
 	github.com/labstack/echo v3.3.10+incompatible
 	github.com/labstack/gommon v0.3.0 // indirect
@@ -21,6 +22,7 @@ require (
 	golang.org/x/net v0.0.0-20210405180319-a5a99cb37ef4 // indirect
 	golang.org/x/sys v0.0.0-20210403161142-5e06dd20ab57
 	golang.org/x/text v0.3.6 // indirect
+	gopkg.in/yaml.v2 v2.4.0
 )
 
 //replace github.com/darimuri/go-lib v0.1.8 => ../go-lib
@@ -1,18 +1,23 @@
 package adaptor
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"log"
-	"net/url"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/darimuri/coll-news/pkg/cache"
 	"github.com/darimuri/coll-news/pkg/types"
 	"github.com/darimuri/coll-news/pkg/util"
 	rt "github.com/darimuri/go-lib/rodtemplate"
+	"github.com/go-rod/rod"
 	"github.com/go-rod/rod/lib/cdp"
+	"github.com/go-rod/rod/lib/proto"
 )
 
 var _ error = (*TypedError)(nil)
@@ -31,31 +36,71 @@ func (t TypedError) Error() string {
 
 var (
 	CPBlockNotFound = TypedError{err: errors.New("content provider block is missing")}
+	// OutOfPortal is the end of a news linking out of the portal, such as press sites of the newsstand, which is
+	// skipped without failing the collection.
+	OutOfPortal = TypedError{err: errors.New("end out of the portal is not supported")}
+)
+
+const (
+	maxIdleTabs  = 32
+	aliveTimeout = time.Second * 10
 )
 
 type Adaptor struct {
 	*rt.BrowserTemplate
 	*rt.PageTemplate
-	Cache cache.Cache
+	Cache    cache.Cache
+	CacheTTL time.Duration
 
 	Profile   types.Profile
 	Collector types.TypedCollector
 	DumpRoot  string
+	// Comments are collected with ends by collectors which are also CommentCollector.
+	Comments types.CommentOption
+	// Hijack is the recording or replay of the browser, closed on Cleanup before the browser.
+	Hijack io.Closer
+
+	tabs     chan *rod.Page
+	tabsOnce sync.Once
+
+	url string
 }
 
 func (a *Adaptor) Cleanup() {
+	if a.Hijack != nil {
+		if err := a.Hijack.Close(); err != nil {
+			log.Println("failed to close hijacking of browser for", err)
+		}
+	}
+
 	for _, pg := range a.MustPages() {
 		pg.MustClose()
 	}
 	a.Browser.MustClose()
 }
 
-func (a *Adaptor) Open(url string) {
+// Alive asks the browser its version, so a hung or disconnected chrome is noticed.
+func (a *Adaptor) Alive() error {
+	_, err := proto.BrowserGetVersion{}.Call(a.Browser.Timeout(aliveTimeout))
+
+	return err
+}
+
+// Open navigates to url and waits the page until it is idle or ctx is done.
+func (a *Adaptor) Open(ctx context.Context, url string) {
+	a.url = url
+
 	page := a.BrowserTemplate.MustPage(url)
 	a.PageTemplate = rt.NewPageTemplate(page)
 	a.SetViewport(a.Profile.Width, a.Profile.Height)
 
+	page = page.Context(ctx)
+
 	if err := page.WaitLoad(); err != nil {
+		if ctx.Err() != nil {
+			log.Println("stop waiting", url, "to load for", ctx.Err())
+			return
+		}
 		if false == cdp.ErrCtxDestroyed.Is(err) {
 			panic(err)
 		}
@@ -63,6 +108,10 @@ func (a *Adaptor) Open(url string) {
 	}
 
 	if err := page.WaitIdle(time.Minute * 10); err != nil {
+		if ctx.Err() != nil {
+			log.Println("stop waiting", url, "to be idle for", ctx.Err())
+			return
+		}
 		if false == cdp.ErrCtxDestroyed.Is(err) {
 			panic(err)
 		}
@@ -70,10 +119,12 @@ func (a *Adaptor) Open(url string) {
 	}
 }
 
-func (a *Adaptor) OpenTab(url string) {
-	page := a.BrowserTemplate.MustPages().First().MustNavigate(url)
-	a.PageTemplate = rt.NewPageTemplate(page)
-	a.SetViewport(a.Profile.Width, a.Profile.Height)
+// OpenTab navigates a page taken from the tab pool and returns its own template,
+// so ends collected at the same time do not share page state.
+func (a *Adaptor) OpenTab(page *rod.Page, url string) *rt.PageTemplate {
+	page = page.MustNavigate(url)
+	p := rt.NewPageTemplate(page)
+	p.SetViewport(a.Profile.Width, a.Profile.Height)
 
 	if err := page.WaitLoad(); err != nil {
 		if false == cdp.ErrCtxDestroyed.Is(err) {
@@ -88,9 +139,11 @@ func (a *Adaptor) OpenTab(url string) {
 		}
 		log.Println(err.Error(), "failed to wait idle for 10m, but has no problem")
 	}
+
+	return p
 }
 
-func (a *Adaptor) GetTopNewsList() (news []types.News, retErr error) {
+func (a *Adaptor) GetTopNewsList(ctx context.Context) (news []types.News, retErr error) {
 	defer func() {
 		v := recover()
 		retErr = util.PanicAsError(v)
@@ -99,7 +152,7 @@ func (a *Adaptor) GetTopNewsList() (news []types.News, retErr error) {
 	a.ScrollBottomHuman()
 	a.WaitLoadAndIdle()
 
-	dd := types.DumpDirectory{RootPath: a.DumpRoot, Source: "top", DumpTime: time.Now()}
+	dd := types.DumpDirectory{RootPath: a.DumpRoot, Source: "top", DumpTime: time.Now(), URL: a.url}
 	if err := dd.Init(); err != nil {
 		return nil, err
 	}
@@ -111,21 +164,21 @@ func (a *Adaptor) GetTopNewsList() (news []types.News, retErr error) {
 		return nil, err
 	}
 
-	return a.Collector.GetTopNewsList(a.PageTemplate, dd)
+	return a.Collector.GetTopNewsList(ctx, a.PageTemplate, dd)
 }
 
-func (a *Adaptor) GetNewsHomeNewsList() (news []types.News, retErr error) {
+func (a *Adaptor) GetNewsHomeNewsList(ctx context.Context) (news []types.News, retErr error) {
 	defer func() {
 		v := recover()
 		retErr = util.PanicAsError(v)
 	}()
 
-	dd := types.DumpDirectory{RootPath: a.DumpRoot, Source: "news", DumpTime: time.Now()}
+	dd := types.DumpDirectory{RootPath: a.DumpRoot, Source: "news", DumpTime: time.Now(), URL: a.url}
 	if err := dd.Init(); err != nil {
 		return nil, err
 	}
 
-	a.Collector.PrepareNewsHomeScreenShot(a.PageTemplate)
+	a.Collector.PrepareNewsHomeScreenShot(ctx, a.PageTemplate)
 	a.PageTemplate.ScreenShotFull(dd.FullScreenShot())
 
 	err := ioutil.WriteFile(dd.FullHTML(), []byte(a.PageTemplate.HTML()), 0644)
@@ -133,12 +186,30 @@ func (a *Adaptor) GetNewsHomeNewsList() (news []types.News, retErr error) {
 		return nil, err
 	}
 
-	return a.Collector.GetNewsHomeNewsList(a.PageTemplate, dd)
+	return a.Collector.GetNewsHomeNewsList(ctx, a.PageTemplate, dd)
 }
 
-func (a *Adaptor) GetNewsEnd(n *types.News) (retErr error) {
+type refreshKey struct{}
+
+// WithRefresh makes ends collected under ctx collected again instead of taken from the cache, e.g. to revisit them.
+func WithRefresh(ctx context.Context) context.Context {
+	return context.WithValue(ctx, refreshKey{}, true)
+}
+
+func refreshed(ctx context.Context) bool {
+	refresh, _ := ctx.Value(refreshKey{}).(bool)
+	return refresh
+}
+
+// GetNewsEnd collects the end of n unless ctx is already done. An end being collected is not
+// interrupted by ctx, so shutdown stops after the current end.
+func (a *Adaptor) GetNewsEnd(ctx context.Context, n *types.News) (retErr error) {
 	var end interface{}
 
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	defer func() {
 		v := recover()
 		if v == nil {
@@ -156,17 +227,22 @@ func (a *Adaptor) GetNewsEnd(n *types.News) (retErr error) {
 	}()
 
 	cacheKey := asKey(n.URL)
-	end, retErr = a.Cache.Get(cacheKey, &types.End{})
-	if retErr != nil {
-		return
-	}
+	if false == refreshed(ctx) {
+		end, retErr = a.Cache.Get(cacheKey, &types.End{})
+		if retErr != nil {
+			return
+		}
 
-	if end != nil {
-		n.End = end.(*types.End)
-		return
+		if end != nil {
+			n.End = end.(*types.End)
+			return
+		}
 	}
 
-	a.OpenTab(n.URL)
+	page := a.acquireTab()
+	defer a.releaseTab(page)
+
+	p := a.OpenTab(page, n.URL)
 
 	collectedAt := time.Now()
 
@@ -185,7 +261,7 @@ func (a *Adaptor) GetNewsEnd(n *types.News) (retErr error) {
 
 	}()
 
-	retErr = a.Collector.GetNewsEnd(a.PageTemplate, n)
+	retErr = a.Collector.GetNewsEnd(ctx, p, n)
 	if retErr != nil {
 		return
 	}
@@ -194,13 +270,134 @@ func (a *Adaptor) GetNewsEnd(n *types.News) (retErr error) {
 		n.End.CollectedAt = collectedAt.Format(types.DataDateTimeFormat)
 		n.End.PostedAt = convertToDataFormat(n.End.PostedAt)
 		n.End.ModifiedAt = convertToDataFormat(n.End.ModifiedAt)
+
+		a.getComments(ctx, p, n)
+	}
+
+	cacheTTL := a.CacheTTL
+	if cacheTTL <= 0 {
+		cacheTTL = cache.DefaultTTL
 	}
 
-	retErr = a.Cache.Set(cacheKey, n.End, time.Minute*3)
+	retErr = a.Cache.Set(cacheKey, n.End, cacheTTL)
 
 	return
 }
 
+// getComments collects top comments of the end of n when it is asked and the collector can. The end is kept
+// without comments when they fail.
+func (a *Adaptor) getComments(ctx context.Context, p *rt.PageTemplate, n *types.News) {
+	if a.Comments.Limit <= 0 {
+		return
+	}
+
+	cc, ok := a.Collector.(types.CommentCollector)
+	if false == ok {
+		return
+	}
+
+	err := func() (retErr error) {
+		defer func() {
+			if v := recover(); v != nil {
+				retErr = util.PanicAsError(v)
+			}
+		}()
+
+		return cc.GetComments(ctx, p, n, a.Comments)
+	}()
+	if err != nil {
+		log.Println("end of", n.URL, "is kept without comments for", err)
+		n.End.Comments = nil
+		return
+	}
+
+	n.End.CommentSort = a.Comments.Sort
+}
+
+// GetNewsEnds collects ends of news with up to concurrency tabs at once. Errors are returned at the
+// index of the news they belong to. With stopOnError, ends not started yet are skipped after an error.
+// Once ctx is done, ends not started yet get the error of ctx.
+func (a *Adaptor) GetNewsEnds(ctx context.Context, news []types.News, concurrency int, stopOnError bool) []error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	errs := make([]error, len(news))
+	indexes := make(chan int)
+
+	var numProcessed int64
+	var stopped int32
+
+	wg := sync.WaitGroup{}
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for idx := range indexes {
+				if stopOnError && atomic.LoadInt32(&stopped) == 1 {
+					continue
+				}
+
+				if err := ctx.Err(); err != nil {
+					errs[idx] = err
+					continue
+				}
+
+				errs[idx] = a.getNewsEndSafe(ctx, &news[idx])
+				if errs[idx] != nil && false == errors.Is(errs[idx], OutOfPortal) {
+					atomic.StoreInt32(&stopped, 1)
+				}
+
+				processed := atomic.AddInt64(&numProcessed, 1)
+				if processed > 10 && processed%10 == 1 {
+					log.Printf("processed %d percent of news end\n", (processed*100)/int64(len(news)))
+				}
+			}
+		}()
+	}
+
+	for idx := range news {
+		indexes <- idx
+	}
+	close(indexes)
+
+	wg.Wait()
+
+	return errs
+}
+
+func (a *Adaptor) getNewsEndSafe(ctx context.Context, n *types.News) (retErr error) {
+	defer func() {
+		if v := recover(); v != nil {
+			retErr = util.PanicAsError(v)
+		}
+	}()
+
+	return a.GetNewsEnd(ctx, n)
+}
+
+func (a *Adaptor) acquireTab() *rod.Page {
+	a.tabsOnce.Do(func() {
+		a.tabs = make(chan *rod.Page, maxIdleTabs)
+	})
+
+	select {
+	case page := <-a.tabs:
+		return page
+	default:
+		return a.BrowserTemplate.MustPage("")
+	}
+}
+
+func (a *Adaptor) releaseTab(page *rod.Page) {
+	select {
+	case a.tabs <- page:
+	default:
+		_ = page.Close()
+	}
+}
+
 func convertToDataFormat(at string) string {
 	if at == "" {
 		return ""
@@ -208,11 +405,15 @@ func convertToDataFormat(at string) string {
 
 	layout := "2006. 01. 02. 15:04"
 	layoutFallback := "2006.01.02 15:04"
+	layoutNaver := "2006-01-02 15:04:05"
 	dt, err := time.ParseInLocation(layout, at, time.Local)
 	if err != nil {
 		dt, err = time.Parse(layoutFallback, at)
+	}
+	if err != nil {
+		dt, err = time.ParseInLocation(layoutNaver, at, time.Local)
 		if err != nil {
-			log.Println("failed to parse", at, "with", layoutFallback, "for", err.Error())
+			log.Printf("failed to parse %q with any of %q, %q and %q\n", at, layout, layoutFallback, layoutNaver)
 			return at
 		}
 	}
@@ -221,10 +422,10 @@ func convertToDataFormat(at string) string {
 }
 
 func asKey(k string) string {
-	u, err := url.Parse(k)
+	key, err := util.NormalizeURL(k)
 	if err != nil {
-		panic(fmt.Errorf("failed to parse url %s for error: %v", k, err))
+		panic(err)
 	}
 
-	return fmt.Sprintf("%s://%s%s", u.Scheme, u.Host, u.Path)
+	return key
 }
@@ -0,0 +1,58 @@
+package adaptor
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/darimuri/coll-news/pkg/types"
+	"github.com/darimuri/coll-news/pkg/util"
+)
+
+// Reparse loads a saved full html dump into a local page and runs the typed collector against it again.
+// Tab screenshots are written under reparse so that the ones taken while collecting are kept.
+func (a *Adaptor) Reparse(ctx context.Context, loc types.Loc, htmlFile string) (news []types.News, retErr error) {
+	defer func() {
+		if v := recover(); v != nil {
+			retErr = util.PanicAsError(v)
+		}
+	}()
+
+	absPath, err := filepath.Abs(htmlFile)
+	if err != nil {
+		return nil, err
+	}
+
+	a.Open(ctx, (&url.URL{Scheme: "file", Path: absPath}).String())
+
+	dd := types.DumpDirectory{RootPath: a.DumpRoot, Source: "reparse", DumpTime: time.Now(), URL: a.url}
+	if err = dd.Init(); err != nil {
+		return nil, err
+	}
+
+	switch loc {
+	case types.Top:
+		news, err = a.Collector.GetTopNewsList(ctx, a.PageTemplate, dd)
+	case types.Home:
+		a.Collector.PrepareNewsHomeScreenShot(ctx, a.PageTemplate)
+		news, err = a.Collector.GetNewsHomeNewsList(ctx, a.PageTemplate, dd)
+	default:
+		return nil, fmt.Errorf("location %s can not be reparsed", loc)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	fullScreenShot := strings.TrimSuffix(htmlFile, ".html") + ".jpg"
+	for i := range news {
+		news[i].Location = loc
+		news[i].FullHTML = htmlFile
+		news[i].FullScreenShot = fullScreenShot
+	}
+
+	return news, nil
+}
@@ -3,51 +3,100 @@ package cache
 import (
 	"bytes"
 	"encoding/gob"
+	"fmt"
+	"net/url"
+	"path/filepath"
 	"reflect"
+	"sync/atomic"
 	"time"
+)
+
+const (
+	schemeMemory = "memory"
+	schemeFile   = "file"
+	schemeRedis  = "redis"
 
-	ac "github.com/PraserX/atomic-cache"
+	// URLDesc describes the cache urls accepted by Open.
+	URLDesc = "memory://, file:///path/to/dir or redis://[:password@]host:port[/db][?prefix=coll-news:end:]"
 )
 
-type Cache struct {
-	ac *ac.AtomicCache
+// Cache keeps collected ends between collections so the same article is not scraped again.
+type Cache interface {
+	Get(k string, v interface{}) (interface{}, error)
+	Set(k string, v interface{}, expire time.Duration) error
+	Delete(k string) error
+	Stats() Stats
 }
 
-func (c Cache) Get(k string, v interface{}) (interface{}, error) {
-	if byteArr, err := c.ac.Get([]byte(k)); err != nil {
-		if ac.ErrNotFound == err {
-			return nil, nil
-		}
-		return nil, err
-	} else {
-		dec := gob.NewDecoder(bytes.NewReader(byteArr))
-		if err = dec.Decode(v); err != nil {
-			return nil, err
-		}
+// Stats counts lookups of a cache since it was opened.
+type Stats struct {
+	Hits   uint64
+	Misses uint64
+}
+
+// Open creates the cache of the backend named by scheme of rawURL.
+func Open(rawURL string) (Cache, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse cache url %s for %v", rawURL, err)
 	}
 
-	return v, nil
+	switch u.Scheme {
+	case schemeMemory:
+		return NewLargeCache(), nil
+	case schemeFile:
+		return NewDiskCache(filepath.Join(u.Host, u.Path))
+	case schemeRedis:
+		return NewRedisCache(u)
+	default:
+		return nil, fmt.Errorf("cache url should be one of %s. not %s", URLDesc, rawURL)
+	}
+}
+
+type counter struct {
+	hits   uint64
+	misses uint64
 }
 
-func (c Cache) Set(k string, v interface{}, expire time.Duration) error {
-	if true == reflect.ValueOf(v).IsNil() {
-		return nil
+func (c *counter) count(found bool) {
+	if found {
+		atomic.AddUint64(&c.hits, 1)
+	} else {
+		atomic.AddUint64(&c.misses, 1)
 	}
+}
 
-	buf := bytes.Buffer{}
-	enc := gob.NewEncoder(&buf)
+func (c *counter) Stats() Stats {
+	return Stats{Hits: atomic.LoadUint64(&c.hits), Misses: atomic.LoadUint64(&c.misses)}
+}
 
-	if err := enc.Encode(v); err != nil {
-		return err
+func encode(v interface{}) ([]byte, error) {
+	buf := bytes.Buffer{}
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
 	}
 
-	if err := c.ac.Set([]byte(k), buf.Bytes(), expire); err != nil {
-		return err
+	return buf.Bytes(), nil
+}
+
+func decode(byteArr []byte, v interface{}) (interface{}, error) {
+	if err := gob.NewDecoder(bytes.NewReader(byteArr)).Decode(v); err != nil {
+		return nil, err
 	}
 
-	return nil
+	return v, nil
 }
 
-func NewLargeCache() Cache {
-	return Cache{ac.New(ac.OptionMaxRecords(512), ac.OptionRecordSizeLarge(65536*8), ac.OptionMaxShardsSmall(48))}
+func isNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface:
+		return rv.IsNil()
+	}
+
+	return false
 }
@@ -0,0 +1,177 @@
+package cache
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type record struct {
+	Title string
+	Count uint64
+}
+
+// behavesAsCache checks a cache opened by open, elapse passes time for expiry of records.
+func behavesAsCache(open func() Cache, elapse func(d time.Duration)) {
+	var c Cache
+
+	BeforeEach(func() {
+		c = open()
+	})
+
+	It("returns what is set until deleted", func() {
+		got, err := c.Get("https://news.daum.net/v/1", &record{})
+		Expect(err).Should(BeNil())
+		Expect(got).Should(BeNil())
+
+		err = c.Set("https://news.daum.net/v/1", &record{Title: "title", Count: 3}, time.Minute)
+		Expect(err).Should(BeNil())
+
+		got, err = c.Get("https://news.daum.net/v/1", &record{})
+		Expect(err).Should(BeNil())
+		Expect(got).Should(Equal(&record{Title: "title", Count: 3}))
+
+		Expect(c.Delete("https://news.daum.net/v/1")).Should(BeNil())
+		Expect(c.Delete("https://news.daum.net/v/1")).Should(BeNil())
+
+		got, err = c.Get("https://news.daum.net/v/1", &record{})
+		Expect(err).Should(BeNil())
+		Expect(got).Should(BeNil())
+
+		Expect(c.Stats()).Should(Equal(Stats{Hits: 1, Misses: 2}))
+	})
+
+	It("forgets expired records", func() {
+		err := c.Set("https://news.daum.net/v/2", &record{Title: "title"}, time.Millisecond*10)
+		Expect(err).Should(BeNil())
+
+		elapse(time.Millisecond * 50)
+
+		got, err := c.Get("https://news.daum.net/v/2", &record{})
+		Expect(err).Should(BeNil())
+		Expect(got).Should(BeNil())
+	})
+
+	It("ignores nil values", func() {
+		var r *record
+		Expect(c.Set("https://news.daum.net/v/3", r, time.Minute)).Should(BeNil())
+
+		got, err := c.Get("https://news.daum.net/v/3", &record{})
+		Expect(err).Should(BeNil())
+		Expect(got).Should(BeNil())
+	})
+}
+
+var _ = Describe("memory cache", func() {
+	behavesAsCache(func() Cache {
+		c, err := Open("memory://")
+		Expect(err).Should(BeNil())
+		return c
+	}, time.Sleep)
+})
+
+var _ = Describe("disk cache", func() {
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "cache")
+		Expect(err).Should(BeNil())
+	})
+
+	AfterEach(func() {
+		_ = os.RemoveAll(dir)
+	})
+
+	behavesAsCache(func() Cache {
+		c, err := Open("file://" + dir)
+		Expect(err).Should(BeNil())
+		return c
+	}, time.Sleep)
+})
+
+var _ = Describe("redis cache", func() {
+	var server *miniredis.Miniredis
+
+	BeforeEach(func() {
+		var err error
+		server, err = miniredis.Run()
+		Expect(err).Should(BeNil())
+		server.RequireAuth("secret")
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	behavesAsCache(func() Cache {
+		c, err := Open(fmt.Sprintf("redis://:secret@%s/2?prefix=test:", server.Addr()))
+		Expect(err).Should(BeNil())
+		return c
+	}, func(d time.Duration) {
+		// miniredis expires keys only as its clock is forwarded
+		server.FastForward(d)
+	})
+
+	It("keeps records under prefix of the selected database", func() {
+		c, err := Open(fmt.Sprintf("redis://:secret@%s/2?prefix=test:", server.Addr()))
+		Expect(err).Should(BeNil())
+
+		Expect(c.Set("k", &record{Title: "title"}, time.Minute)).Should(BeNil())
+		Expect(server.DB(2).Keys()).Should(ConsistOf("test:k"))
+		Expect(server.DB(0).Keys()).Should(BeEmpty())
+	})
+
+	It("fails to open without password", func() {
+		_, err := Open(fmt.Sprintf("redis://%s", server.Addr()))
+		Expect(err).ShouldNot(BeNil())
+	})
+})
+
+var _ = Describe("cache url", func() {
+	It("rejects unknown scheme", func() {
+		_, err := Open("bolt:///tmp/cache")
+		Expect(err).ShouldNot(BeNil())
+	})
+})
+
+var _ = Describe("cache ttl", func() {
+	It("parses ttls for every source and by source", func() {
+		t, err := ParseTTLs("daum=3m,naver=10m")
+		Expect(err).Should(BeNil())
+		Expect(t.Of("daum")).Should(Equal(time.Minute * 3))
+		Expect(t.Of("naver")).Should(Equal(time.Minute * 10))
+
+		t, err = ParseTTLs("5m,naver=10m")
+		Expect(err).Should(BeNil())
+		Expect(t.Of("daum")).Should(Equal(time.Minute * 5))
+		Expect(t.Of("naver")).Should(Equal(time.Minute * 10))
+
+		t, err = ParseTTLs("")
+		Expect(err).Should(BeNil())
+		Expect(t.Of("daum")).Should(Equal(DefaultTTL))
+
+		for _, invalid := range []string{"daum=", "=3m", "naver=-1m", "3"} {
+			_, err = ParseTTLs(invalid)
+			Expect(err).ShouldNot(BeNil(), invalid)
+		}
+	})
+
+	It("keeps records for the ttl instead of the expire asked", func() {
+		server, err := miniredis.Run()
+		Expect(err).Should(BeNil())
+		defer server.Close()
+
+		c, err := Open(fmt.Sprintf("redis://%s", server.Addr()))
+		Expect(err).Should(BeNil())
+
+		c = WithTTL(c, time.Minute*10)
+		Expect(c.Set("k", &record{Title: "title"}, time.Minute)).Should(BeNil())
+		Expect(server.TTL(defaultRedisPrefix + "k")).Should(Equal(time.Minute * 10))
+	})
+})
@@ -0,0 +1,168 @@
+package cache
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/gob"
+	"encoding/hex"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const diskRecordExt = ".gob"
+
+var _ Cache = (*disk)(nil)
+
+// disk keeps each record as a gob file under dir, so records survive restarts of the collector.
+type disk struct {
+	*counter
+
+	dir string
+}
+
+type diskRecord struct {
+	ExpireAt time.Time
+	Value    []byte
+}
+
+func NewDiskCache(dir string) (Cache, error) {
+	if err := os.MkdirAll(dir, os.FileMode(0700)); err != nil {
+		return nil, err
+	}
+
+	d := disk{counter: &counter{}, dir: dir}
+	d.prune()
+
+	return d, nil
+}
+
+func (d disk) Get(k string, v interface{}) (interface{}, error) {
+	record, err := d.read(d.path(k))
+	if err != nil {
+		if os.IsNotExist(err) {
+			d.count(false)
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if time.Now().After(record.ExpireAt) {
+		_ = os.Remove(d.path(k))
+		d.count(false)
+		return nil, nil
+	}
+
+	d.count(true)
+
+	return decode(record.Value, v)
+}
+
+func (d disk) Set(k string, v interface{}, expire time.Duration) error {
+	if true == isNil(v) {
+		return nil
+	}
+
+	value, err := encode(v)
+	if err != nil {
+		return err
+	}
+
+	buf, err := encode(diskRecord{ExpireAt: time.Now().Add(expire), Value: value})
+	if err != nil {
+		return err
+	}
+
+	p := d.path(k)
+	if err := os.MkdirAll(filepath.Dir(p), os.FileMode(0700)); err != nil {
+		return err
+	}
+
+	// write aside and rename, so concurrent readers never see a half written record
+	tmp, err := ioutil.TempFile(filepath.Dir(p), ".tmp-")
+	if err != nil {
+		return err
+	}
+
+	if _, err = tmp.Write(buf); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+
+	if err = tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), p)
+}
+
+func (d disk) Delete(k string) error {
+	if err := os.Remove(d.path(k)); err != nil && false == os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (d disk) read(p string) (diskRecord, error) {
+	record := diskRecord{}
+
+	byteArr, err := ioutil.ReadFile(p)
+	if err != nil {
+		return record, err
+	}
+
+	err = gob.NewDecoder(bytes.NewReader(byteArr)).Decode(&record)
+
+	return record, err
+}
+
+func (d disk) path(k string) string {
+	sum := sha1.Sum([]byte(k))
+	name := hex.EncodeToString(sum[:])
+
+	return filepath.Join(d.dir, name[:2], name+diskRecordExt)
+}
+
+// prune removes expired records and leftovers of interrupted writes.
+func (d disk) prune() {
+	now := time.Now()
+	numRemoved := 0
+
+	err := filepath.Walk(d.dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return nil
+		}
+
+		if strings.HasPrefix(info.Name(), ".tmp-") {
+			_ = os.Remove(p)
+			return nil
+		}
+
+		if false == strings.HasSuffix(info.Name(), diskRecordExt) {
+			return nil
+		}
+
+		record, errRead := d.read(p)
+		if errRead != nil || now.After(record.ExpireAt) {
+			if os.Remove(p) == nil {
+				numRemoved++
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		log.Println("failed to prune cache directory", d.dir, "for", err)
+	}
+
+	if numRemoved > 0 {
+		log.Printf("pruned %d numbers of expired records from %s\n", numRemoved, d.dir)
+	}
+}
@@ -0,0 +1,101 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+const largeCacheMaxRecords = 512
+
+var _ Cache = (*memory)(nil)
+
+// memory keeps records in process, so it is lost when the collector exits.
+type memory struct {
+	*counter
+
+	mu         sync.Mutex
+	maxRecords int
+	records    map[string]memoryRecord
+}
+
+type memoryRecord struct {
+	expireAt time.Time
+	value    []byte
+}
+
+func NewLargeCache() Cache {
+	return &memory{counter: &counter{}, maxRecords: largeCacheMaxRecords, records: make(map[string]memoryRecord)}
+}
+
+func (c *memory) Get(k string, v interface{}) (interface{}, error) {
+	c.mu.Lock()
+	record, ok := c.records[k]
+	if ok && time.Now().After(record.expireAt) {
+		delete(c.records, k)
+		ok = false
+	}
+	c.mu.Unlock()
+
+	c.count(ok)
+
+	if false == ok {
+		return nil, nil
+	}
+
+	return decode(record.value, v)
+}
+
+func (c *memory) Set(k string, v interface{}, expire time.Duration) error {
+	if true == isNil(v) {
+		return nil
+	}
+
+	byteArr, err := encode(v)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.records[k]; false == ok && len(c.records) >= c.maxRecords {
+		c.evict()
+	}
+
+	c.records[k] = memoryRecord{expireAt: time.Now().Add(expire), value: byteArr}
+
+	return nil
+}
+
+func (c *memory) Delete(k string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.records, k)
+
+	return nil
+}
+
+// evict drops expired records, or the record closest to expiration when none is expired.
+func (c *memory) evict() {
+	now := time.Now()
+
+	var oldestKey string
+	var oldest time.Time
+
+	for k, r := range c.records {
+		if now.After(r.expireAt) {
+			delete(c.records, k)
+			continue
+		}
+
+		if oldestKey == "" || r.expireAt.Before(oldest) {
+			oldestKey = k
+			oldest = r.expireAt
+		}
+	}
+
+	if len(c.records) >= c.maxRecords && oldestKey != "" {
+		delete(c.records, oldestKey)
+	}
+}
@@ -0,0 +1,51 @@
+package cache
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var endCacheRequests = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Namespace: "coll_news",
+		Name:      "end_cache_requests_total",
+		Help:      "Number of end cache lookups by result(hit/miss).",
+	},
+	[]string{"source", "type", "result"},
+)
+
+func init() {
+	prometheus.MustRegister(endCacheRequests)
+}
+
+var _ Cache = (*instrumented)(nil)
+
+type instrumented struct {
+	Cache
+
+	hit  prometheus.Counter
+	miss prometheus.Counter
+}
+
+// WithMetrics counts hits and misses of c on /metrics labelled by source and type.
+func WithMetrics(c Cache, source, collectType string) Cache {
+	return instrumented{
+		Cache: c,
+		hit:   endCacheRequests.WithLabelValues(source, collectType, "hit"),
+		miss:  endCacheRequests.WithLabelValues(source, collectType, "miss"),
+	}
+}
+
+func (i instrumented) Get(k string, v interface{}) (interface{}, error) {
+	got, err := i.Cache.Get(k, v)
+	if err != nil {
+		return got, err
+	}
+
+	if got != nil {
+		i.hit.Inc()
+	} else {
+		i.miss.Inc()
+	}
+
+	return got, nil
+}
@@ -0,0 +1,113 @@
+package cache
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	goredis "github.com/go-redis/redis/v7"
+)
+
+const (
+	defaultRedisPrefix  = "coll-news:end:"
+	redisDialTimeout    = time.Second * 5
+	redisIOTimeout      = time.Second * 10
+	redisPoolSize       = 8
+	redisDefaultAddress = "localhost:6379"
+)
+
+var _ Cache = (*redis)(nil)
+
+// redis keeps records on a redis server, so collectors on several hosts share ends.
+type redis struct {
+	*counter
+
+	client *goredis.Client
+	prefix string
+}
+
+// NewRedisCache connects to redis://[:password@]host:port[/db][?prefix=...] and checks the server answers.
+func NewRedisCache(u *url.URL) (Cache, error) {
+	options := &goredis.Options{
+		Addr:         u.Host,
+		DialTimeout:  redisDialTimeout,
+		ReadTimeout:  redisIOTimeout,
+		WriteTimeout: redisIOTimeout,
+		PoolSize:     redisPoolSize,
+	}
+
+	if options.Addr == "" {
+		options.Addr = redisDefaultAddress
+	}
+
+	if u.User != nil {
+		if password, ok := u.User.Password(); ok {
+			options.Password = password
+		} else {
+			options.Password = u.User.Username()
+		}
+	}
+
+	if db := strings.Trim(u.Path, "/"); db != "" {
+		n, err := strconv.Atoi(db)
+		if err != nil {
+			return nil, fmt.Errorf("redis database of cache url should be a number. not %s", db)
+		}
+		options.DB = n
+	}
+
+	c := &redis{
+		counter: &counter{},
+		client:  goredis.NewClient(options),
+		prefix:  defaultRedisPrefix,
+	}
+
+	if prefix, ok := u.Query()["prefix"]; ok {
+		c.prefix = prefix[0]
+	}
+
+	if err := c.client.Ping().Err(); err != nil {
+		_ = c.client.Close()
+		return nil, fmt.Errorf("failed to connect redis %s for %v", options.Addr, err)
+	}
+
+	return c, nil
+}
+
+func (c *redis) Get(k string, v interface{}) (interface{}, error) {
+	reply, err := c.client.Get(c.prefix + k).Bytes()
+	if err == goredis.Nil {
+		c.count(false)
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	c.count(true)
+
+	return decode(reply, v)
+}
+
+func (c *redis) Set(k string, v interface{}, expire time.Duration) error {
+	if true == isNil(v) {
+		return nil
+	}
+
+	byteArr, err := encode(v)
+	if err != nil {
+		return err
+	}
+
+	// expire less than a millisecond would keep the record forever
+	if expire < time.Millisecond {
+		expire = time.Millisecond
+	}
+
+	return c.client.Set(c.prefix+k, byteArr, expire).Err()
+}
+
+func (c *redis) Delete(k string) error {
+	return c.client.Del(c.prefix + k).Err()
+}
@@ -0,0 +1,96 @@
+package cache
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultTTL is how long ends are kept for sources without a ttl of their own.
+const DefaultTTL = time.Minute * 3
+
+// TTLs are how long ends are kept by source, Default for sources not named.
+type TTLs struct {
+	Default time.Duration
+	Sources map[string]time.Duration
+}
+
+// ParseTTLs reads ttls such as 3m for every source, daum=3m,naver=10m by source or 5m,naver=10m for naver and the
+// others.
+func ParseTTLs(s string) (TTLs, error) {
+	t := TTLs{Default: DefaultTTL, Sources: make(map[string]time.Duration)}
+
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		source, value := "", part
+		if idx := strings.Index(part, "="); idx >= 0 {
+			source, value = strings.TrimSpace(part[:idx]), strings.TrimSpace(part[idx+1:])
+			if source == "" {
+				return TTLs{}, fmt.Errorf("source of ttl %s is empty", part)
+			}
+		}
+
+		ttl, err := time.ParseDuration(value)
+		if err != nil {
+			return TTLs{}, fmt.Errorf("ttl %s is not a duration for %v", part, err)
+		}
+		if ttl <= 0 {
+			return TTLs{}, fmt.Errorf("ttl %s should be positive", part)
+		}
+
+		if source == "" {
+			t.Default = ttl
+		} else {
+			t.Sources[source] = ttl
+		}
+	}
+
+	return t, nil
+}
+
+// Of is the ttl of source.
+func (t TTLs) Of(source string) time.Duration {
+	if ttl, ok := t.Sources[source]; ok {
+		return ttl
+	}
+
+	return t.Default
+}
+
+func (t TTLs) String() string {
+	parts := []string{t.Default.String()}
+
+	sources := make([]string, 0, len(t.Sources))
+	for source := range t.Sources {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+
+	for _, source := range sources {
+		parts = append(parts, fmt.Sprintf("%s=%s", source, t.Sources[source]))
+	}
+
+	return strings.Join(parts, ",")
+}
+
+var _ Cache = (*expiring)(nil)
+
+type expiring struct {
+	Cache
+
+	ttl time.Duration
+}
+
+// WithTTL keeps records set to c for ttl, such as the ttl of the source of a collector, instead of the expire asked.
+func WithTTL(c Cache, ttl time.Duration) Cache {
+	return expiring{Cache: c, ttl: ttl}
+}
+
+func (e expiring) Set(k string, v interface{}, _ time.Duration) error {
+	return e.Cache.Set(k, v, e.ttl)
+}
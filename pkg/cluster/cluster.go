@@ -0,0 +1,243 @@
+package cluster
+
+import (
+	"sort"
+
+	"github.com/darimuri/coll-news/pkg/snapshot"
+	"github.com/darimuri/coll-news/pkg/types"
+	"github.com/darimuri/coll-news/pkg/util"
+)
+
+const (
+	// TextDistance is the most bits fingerprints of the lead of end texts differ in for the same story. A byline
+	// or an edited word of a short lead moves about 8 bits, while unrelated texts differ in about 32.
+	TextDistance = 8
+	// TitleDistance is the most bits fingerprints of titles differ in for the same story.
+	TitleDistance = 6
+)
+
+// Source is news of a run of a portal, Name such as daum.
+type Source struct {
+	Name string
+	Run  string
+	News []types.News
+}
+
+// Member is an article of a cluster as a source lists it, Rank the order of the article in the run of the source.
+type Member struct {
+	Source    string            `json:"source"`
+	URL       string            `json:"url"`
+	Title     string            `json:"title"`
+	Publisher string            `json:"publisher"`
+	Position  snapshot.Position `json:"position"`
+	Rank      int               `json:"rank"`
+}
+
+// Cluster is a story, the near duplicate articles of sources. Ranks is the best rank of the story by source,
+// RankDiff the rank of the first source minus the one of the second when both list the story.
+type Cluster struct {
+	ID       string         `json:"id"`
+	Title    string         `json:"title"`
+	Members  []Member       `json:"members"`
+	Ranks    map[string]int `json:"ranks"`
+	RankDiff *int           `json:"rank_diff,omitempty"`
+}
+
+// Shared tells the story is listed by every one of numSources.
+func (c Cluster) Shared(numSources int) bool {
+	return len(c.Ranks) == numSources
+}
+
+// Report is stories of sources and how much of them the sources share.
+type Report struct {
+	Sources  []string          `json:"sources"`
+	Runs     map[string]string `json:"runs"`
+	Clusters []Cluster         `json:"clusters"`
+	Shared   int               `json:"shared"`
+	Only     map[string]int    `json:"only"`
+}
+
+type article struct {
+	key     string
+	title   uint64
+	text    uint64
+	members []Member
+}
+
+// near tells a and o are the same story, when the leads of their end texts or their titles are near duplicates.
+// Two presses often write their own texts of a story under the same title, and a press retitles its own text.
+func (a *article) near(o *article) bool {
+	if a.text != 0 && o.text != 0 && Distance(a.text, o.text) <= TextDistance {
+		return true
+	}
+
+	return a.title != 0 && o.title != 0 && Distance(a.title, o.title) <= TitleDistance
+}
+
+// Build clusters articles of sources by fingerprints of their titles and the lead of their end texts, sets
+// ClusterID of every news of the sources and reports the clusters, the shared and the best ranked first.
+func Build(sources []Source) Report {
+	articles := make([]*article, 0)
+	byKey := make(map[string]*article)
+
+	r := Report{
+		Sources:  make([]string, 0, len(sources)),
+		Runs:     make(map[string]string),
+		Clusters: make([]Cluster, 0),
+		Only:     make(map[string]int),
+	}
+
+	for _, s := range sources {
+		r.Sources = append(r.Sources, s.Name)
+		r.Runs[s.Name] = s.Run
+
+		ends := make(map[string]*types.End)
+		publishers := make(map[string]string)
+		for _, n := range s.News {
+			key, err := util.NormalizeURL(n.URL)
+			if err != nil {
+				continue
+			}
+
+			if n.End != nil {
+				ends[key] = n.End
+			}
+
+			// the first publisher listed of the url, as a url listed again may leave it out
+			if _, ok := publishers[key]; false == ok && n.Publisher != "" {
+				publishers[key] = n.Publisher
+			}
+		}
+
+		for idx, item := range snapshot.Ranked(s.News) {
+			a, ok := byKey[item.Key]
+			if false == ok {
+				a = &article{key: item.Key, title: SimHash(item.Title)}
+				byKey[item.Key] = a
+				articles = append(articles, a)
+			}
+
+			if end, ok := ends[item.Key]; ok && a.text == 0 {
+				a.text = SimHash(lead(end.Text))
+			}
+
+			a.members = append(a.members, Member{
+				Source:    s.Name,
+				URL:       item.URL,
+				Title:     item.Title,
+				Publisher: publishers[item.Key],
+				Position:  item.Position,
+				Rank:      idx + 1,
+			})
+		}
+	}
+
+	ids := make(map[string]string)
+	for _, group := range link(articles) {
+		c := newCluster(group)
+		for _, a := range group {
+			ids[a.key] = c.ID
+		}
+
+		if len(r.Sources) == 2 && c.Shared(2) {
+			diff := c.Ranks[r.Sources[0]] - c.Ranks[r.Sources[1]]
+			c.RankDiff = &diff
+		}
+
+		if c.Shared(len(r.Sources)) {
+			r.Shared++
+		} else if len(c.Ranks) == 1 {
+			for name := range c.Ranks {
+				r.Only[name]++
+			}
+		}
+
+		r.Clusters = append(r.Clusters, c)
+	}
+
+	for _, s := range sources {
+		for i := range s.News {
+			if key, err := util.NormalizeURL(s.News[i].URL); err == nil {
+				s.News[i].ClusterID = ids[key]
+			}
+		}
+	}
+
+	sort.Slice(r.Clusters, func(i, j int) bool {
+		ci, cj := r.Clusters[i], r.Clusters[j]
+		if ci.Shared(len(r.Sources)) != cj.Shared(len(r.Sources)) {
+			return ci.Shared(len(r.Sources))
+		}
+		if ci.Members[0].Rank != cj.Members[0].Rank {
+			return ci.Members[0].Rank < cj.Members[0].Rank
+		}
+		return ci.ID < cj.ID
+	})
+
+	return r
+}
+
+// newCluster names a group by a hash of the smallest url of its articles, so the same articles are named the same
+// in every report.
+func newCluster(group []*article) Cluster {
+	c := Cluster{Members: make([]Member, 0), Ranks: make(map[string]int)}
+
+	minKey := group[0].key
+	for _, a := range group {
+		if a.key < minKey {
+			minKey = a.key
+		}
+
+		for _, m := range a.members {
+			c.Members = append(c.Members, m)
+			if best, ok := c.Ranks[m.Source]; false == ok || m.Rank < best {
+				c.Ranks[m.Source] = m.Rank
+			}
+		}
+	}
+
+	c.ID, _ = util.URLHash(minKey)
+
+	sort.Slice(c.Members, func(i, j int) bool {
+		if c.Members[i].Rank != c.Members[j].Rank {
+			return c.Members[i].Rank < c.Members[j].Rank
+		}
+		return c.Members[i].Source < c.Members[j].Source
+	})
+	c.Title = c.Members[0].Title
+
+	return c
+}
+
+// link groups articles by complete linkage, an article joins the first group it is near every article of. Near
+// duplicates do not chain, so a story followed by another one with a similar title is not merged into it.
+func link(articles []*article) [][]*article {
+	groups := make([][]*article, 0)
+
+	for _, a := range articles {
+		joined := false
+		for i, group := range groups {
+			if nearAll(a, group) {
+				groups[i] = append(group, a)
+				joined = true
+				break
+			}
+		}
+
+		if false == joined {
+			groups = append(groups, []*article{a})
+		}
+	}
+
+	return groups
+}
+
+func nearAll(a *article, group []*article) bool {
+	for _, o := range group {
+		if false == a.near(o) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,89 @@
+package cluster
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/darimuri/coll-news/pkg/snapshot"
+	"github.com/darimuri/coll-news/pkg/types"
+)
+
+const other = "청년층 주거 안정을 위한 월세 지원이 늘어난다. 16일 정부 발표에 따르면 부모와 따로 사는 청년도 소득 요건을 " +
+	"갖추면 지원받을 수 있게 되며, 관련 예산은 올해보다 두 배 가까이 편성됐다."
+
+const story = "정부가 내년부터 청년 월세 지원 대상을 확대하기로 했다. 국토교통부는 16일 무주택 청년의 주거비 부담을 덜기 위해 " +
+	"월 최대 20만원을 12개월 동안 지원하는 사업의 소득 기준을 완화한다고 밝혔다. 지원 신청은 주민센터와 온라인으로 받는다."
+
+func listed(url, title, text string, order int) types.News {
+	n := types.News{URL: url, Title: title, Location: types.Top, NewsPage: 1, Order: order}
+	if text != "" {
+		n.End = &types.End{Title: title, Text: text}
+	}
+	return n
+}
+
+var _ = Describe("cluster", func() {
+	It("normalizes titles", func() {
+		Expect(Normalize("[속보] 청년 월세 지원, 확대!")).Should(Equal("청년 월세 지원 확대"))
+		Expect(Distance(SimHash("[단독] 청년 월세 지원 확대"), SimHash("청년 월세 지원 확대"))).Should(Equal(0))
+		Expect(SimHash(" ")).Should(Equal(uint64(0)))
+	})
+
+	It("clusters the same story of daum and naver", func() {
+		daum := []types.News{
+			listed("https://v.daum.net/v/1", "청년 월세 지원 확대", story, 1),
+			listed("https://v.daum.net/v/2", "프로야구 가을야구 대진 확정", "", 2),
+			listed("https://v.daum.net/v/1?f=o", "청년 월세 지원 확대", story, 3),
+		}
+		naver := []types.News{
+			listed("https://n.news.naver.com/article/1", "[속보] 청년 월세 지원 대상 넓힌다", "(서울=뉴스1) 홍길동 기자 = "+story, 1),
+			listed("https://n.news.naver.com/article/2", "반도체 수출 석달째 증가", "", 2),
+		}
+
+		r := Build([]Source{{Name: "daum", Run: "a", News: daum}, {Name: "naver", Run: "b", News: naver}})
+
+		Expect(r.Clusters).Should(HaveLen(3))
+		Expect(r.Shared).Should(Equal(1))
+		Expect(r.Only).Should(Equal(map[string]int{"daum": 1, "naver": 1}))
+
+		shared := r.Clusters[0]
+		Expect(shared.Members).Should(HaveLen(2))
+		Expect(shared.Ranks).Should(Equal(map[string]int{"daum": 1, "naver": 1}))
+		Expect(*shared.RankDiff).Should(Equal(0))
+
+		Expect(shared.Members[0].URL).Should(Equal("https://v.daum.net/v/1"))
+		Expect(shared.Members[1].URL).Should(Equal("https://n.news.naver.com/article/1"))
+
+		Expect(daum[0].ClusterID).Should(Equal(shared.ID))
+		Expect(daum[2].ClusterID).Should(Equal(shared.ID))
+		Expect(naver[0].ClusterID).Should(Equal(shared.ID))
+		Expect(daum[1].ClusterID).ShouldNot(Equal(naver[1].ClusterID))
+
+		for _, format := range []string{snapshot.FormatText, snapshot.FormatMD, snapshot.FormatJSON} {
+			out, err := Format(r, format)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(out).Should(ContainSubstring(shared.ID))
+		}
+	})
+	It("clusters texts of different presses under the same title", func() {
+		Expect(Distance(SimHash(lead(story)), SimHash(lead(other)))).Should(BeNumerically(">", TextDistance))
+
+		daum := []types.News{listed("https://v.daum.net/v/1", "청년 월세 지원 확대", story, 1)}
+		naver := []types.News{listed("https://n.news.naver.com/article/1", "[단독] 청년 월세 지원 확대", other, 1)}
+
+		r := Build([]Source{{Name: "daum", Run: "a", News: daum}, {Name: "naver", Run: "b", News: naver}})
+
+		Expect(r.Clusters).Should(HaveLen(1))
+		Expect(r.Shared).Should(Equal(1))
+	})
+
+	It("does not chain near duplicates into a cluster", func() {
+		a := &article{key: "a", title: 1 << 63}
+		b := &article{key: "b", title: 1<<63 | (1<<TitleDistance - 1)}
+		c := &article{key: "c", title: 1<<63 | (1<<(2*TitleDistance) - 1)}
+		Expect(a.near(b) && b.near(c) && false == a.near(c)).Should(BeTrue())
+
+		groups := link([]*article{a, b, c})
+		Expect(groups).Should(Equal([][]*article{{a, b}, {c}}))
+	})
+})
@@ -0,0 +1,109 @@
+package cluster
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/darimuri/coll-news/pkg/snapshot"
+)
+
+// Format renders r as text, a markdown document or json, the formats of snapshot.
+func Format(r Report, format string) (string, error) {
+	switch format {
+	case snapshot.FormatText:
+		return formatText(r), nil
+	case snapshot.FormatMD:
+		return formatMD(r), nil
+	case snapshot.FormatJSON:
+		data, err := json.MarshalIndent(r, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(data) + "\n", nil
+	}
+
+	return "", fmt.Errorf("cluster format %s is not supported", format)
+}
+
+// Save writes r as json to path, such as the clusters file of a run.
+func Save(r Report, path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), os.FileMode(0700)); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, data, os.FileMode(0644))
+}
+
+func summary(r Report) string {
+	runs := make([]string, 0, len(r.Sources))
+	only := make([]string, 0, len(r.Sources))
+	for _, name := range r.Sources {
+		runs = append(runs, fmt.Sprintf("%s(%s)", name, r.Runs[name]))
+		only = append(only, fmt.Sprintf("only %s %d", name, r.Only[name]))
+	}
+
+	return fmt.Sprintf("%s: %d clusters, shared %d, %s", strings.Join(runs, " "), len(r.Clusters), r.Shared, strings.Join(only, ", "))
+}
+
+func ranks(r Report, c Cluster) string {
+	cols := make([]string, 0, len(r.Sources)+1)
+	for _, name := range r.Sources {
+		if rank, ok := c.Ranks[name]; ok {
+			cols = append(cols, fmt.Sprintf("%s %d", name, rank))
+		} else {
+			cols = append(cols, fmt.Sprintf("%s -", name))
+		}
+	}
+
+	if c.RankDiff != nil {
+		cols = append(cols, fmt.Sprintf("diff %+d", *c.RankDiff))
+	}
+
+	return strings.Join(cols, ", ")
+}
+
+func formatText(r Report) string {
+	sb := strings.Builder{}
+	sb.WriteString(summary(r) + "\n")
+
+	for _, c := range r.Clusters {
+		sb.WriteString(fmt.Sprintf("\n%s\t%s\t%s\n", c.ID, ranks(r, c), c.Title))
+		for _, m := range c.Members {
+			sb.WriteString(fmt.Sprintf("  %s\t%d\t%s\t%s\t%s\t%s\n", m.Source, m.Rank, m.Position, m.Publisher, m.Title, m.URL))
+		}
+	}
+
+	return sb.String()
+}
+
+func formatMD(r Report) string {
+	sb := strings.Builder{}
+	sb.WriteString("## " + summary(r) + "\n")
+
+	sb.WriteString("\n|Cluster|Ranks|Title|\n|---|---|---|\n")
+	for _, c := range r.Clusters {
+		sb.WriteString(fmt.Sprintf("|%s|%s|%s|\n", c.ID, ranks(r, c), escapeMD(c.Title)))
+	}
+
+	sb.WriteString("\n|Cluster|Source|Rank|Position|Publisher|Title|URL|\n|---|---|---|---|---|---|---|\n")
+	for _, c := range r.Clusters {
+		for _, m := range c.Members {
+			sb.WriteString(fmt.Sprintf("|%s|%s|%d|%s|%s|%s|%s|\n", c.ID, m.Source, m.Rank, m.Position, escapeMD(m.Publisher), escapeMD(m.Title), m.URL))
+		}
+	}
+
+	return sb.String()
+}
+
+func escapeMD(s string) string {
+	return strings.ReplaceAll(s, "|", "\\|")
+}
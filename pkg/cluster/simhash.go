@@ -0,0 +1,84 @@
+package cluster
+
+import (
+	"hash/fnv"
+	"math/bits"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+const (
+	// shingleSize is the number of runes of a feature. Korean words change with particles, so runes are
+	// taken across words instead of words.
+	shingleSize = 3
+	// textRunes is the number of runes of End.Text taken, the lead which portals keep as the press wrote it.
+	textRunes = 1000
+)
+
+var bracketed = regexp.MustCompile(`\[[^\]]*\]|【[^】]*】|<[^>]*>`)
+
+// Normalize lowers s and drops bracketed tags such as [속보], punctuation and repeated spaces.
+func Normalize(s string) string {
+	s = bracketed.ReplaceAllString(strings.ToLower(s), " ")
+
+	return strings.Join(strings.FieldsFunc(s, func(r rune) bool {
+		return false == unicode.IsLetter(r) && false == unicode.IsDigit(r)
+	}), " ")
+}
+
+// SimHash is a 64 bits fingerprint of rune shingles of normalized s, which differs in a few bits for near
+// duplicates. It is 0 for an empty s.
+func SimHash(s string) uint64 {
+	runes := []rune(Normalize(s))
+	if len(runes) == 0 {
+		return 0
+	}
+
+	if len(runes) < shingleSize {
+		return hash(string(runes))
+	}
+
+	var weights [64]int
+	for i := 0; i+shingleSize <= len(runes); i++ {
+		h := hash(string(runes[i : i+shingleSize]))
+		for b := 0; b < 64; b++ {
+			if h&(1<<uint(b)) != 0 {
+				weights[b]++
+			} else {
+				weights[b]--
+			}
+		}
+	}
+
+	fp := uint64(0)
+	for b, w := range weights {
+		if w > 0 {
+			fp |= 1 << uint(b)
+		}
+	}
+
+	return fp
+}
+
+// Distance is the number of bits two fingerprints differ in.
+func Distance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+func hash(s string) uint64 {
+	h := fnv.New64a()
+	//goland:noinspection GoUnhandledErrorResult
+	h.Write([]byte(s))
+
+	return h.Sum64()
+}
+
+func lead(text string) string {
+	runes := []rune(text)
+	if len(runes) > textRunes {
+		runes = runes[:textRunes]
+	}
+
+	return string(runes)
+}
@@ -3,12 +3,19 @@ package coll
 import (
 	"fmt"
 	"log"
+	"time"
 
+	"github.com/darimuri/coll-news/pkg/adaptor"
 	"github.com/darimuri/coll-news/pkg/cache"
 	"github.com/darimuri/coll-news/pkg/daum"
 	dmobile "github.com/darimuri/coll-news/pkg/daum/mobile"
 	dpc "github.com/darimuri/coll-news/pkg/daum/pc"
+	"github.com/darimuri/coll-news/pkg/naver"
+	nmobile "github.com/darimuri/coll-news/pkg/naver/mobile"
+	npc "github.com/darimuri/coll-news/pkg/naver/pc"
+	"github.com/darimuri/coll-news/pkg/replay"
 	"github.com/darimuri/coll-news/pkg/types"
+	rt "github.com/darimuri/go-lib/rodtemplate"
 	"github.com/go-rod/rod"
 	"github.com/go-rod/rod/lib/devices"
 	"github.com/go-rod/rod/lib/launcher"
@@ -28,19 +35,121 @@ type Option struct {
 	ChromeBin   string
 	SavePath    string
 	UserDataDir string
-	LogLevel    int
-	Headless    bool
-	Logging     bool
+	RecordDir   string
+	ReplayDir   string
+	// SelectorPackDir has selector packs overriding the built-in ones, e.g. daum-pc.yaml.
+	SelectorPackDir string
+	EndCache        cache.Cache
+	EndCacheTTL     time.Duration
+	Comments        types.CommentOption
+	LogLevel        int
+	Headless        bool
+	Logging         bool
 }
 
 func NewCollector(collectSource, collectType string, option Option) (types.Collector, error) {
 	var c types.Collector
+
+	log.Println("new collector with option", option)
+
+	t, err := newTypedCollector(collectSource, collectType, option.SelectorPackDir)
+	if err != nil {
+		return nil, err
+	}
+
+	browser, profile, hijack, err := newBrowser(collectType, option)
+	if err != nil {
+		return nil, err
+	}
+
+	endCache := option.EndCache
+	if endCache == nil {
+		endCache = cache.NewLargeCache()
+	}
+
+	switch collectSource {
+	case Daum:
+		c, err = daum.NewPortal(browser, profile, t, option.SavePath, endCache, option.EndCacheTTL, option.Comments)
+	case Naver:
+		c, err = naver.NewPortal(browser, profile, t, option.SavePath, endCache, option.EndCacheTTL, option.Comments)
+	}
+
+	if err != nil {
+		return nil, err
+	} else if c == nil {
+		return nil, fmt.Errorf("collector source %s, type %s is not supported", collectSource, collectType)
+	}
+
+	if hijack != nil {
+		switch v := c.(type) {
+		case *daum.Collector:
+			v.Hijack = hijack
+		case *naver.Collector:
+			v.Hijack = hijack
+		}
+	}
+
+	return c, nil
+}
+
+// NewAdaptor launches a browser for the typed collector of source and type without binding it to portal urls.
+func NewAdaptor(collectSource, collectType string, option Option) (*adaptor.Adaptor, error) {
+	log.Println("new adaptor with option", option)
+
+	t, err := newTypedCollector(collectSource, collectType, option.SelectorPackDir)
+	if err != nil {
+		return nil, err
+	}
+
+	browser, profile, hijack, err := newBrowser(collectType, option)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &adaptor.Adaptor{BrowserTemplate: rt.NewBrowserTemplate(browser), Profile: profile, Collector: t, DumpRoot: option.SavePath, Cache: cache.NewLargeCache()}
+	if hijack != nil {
+		a.Hijack = hijack
+	}
+
+	return a, nil
+}
+
+func newTypedCollector(collectSource, collectType, selectorPackDir string) (types.TypedCollector, error) {
 	var t types.TypedCollector
+	var err error
+
+	switch collectSource {
+	case Daum:
+		switch collectType {
+		case Mobile:
+			t, err = dmobile.Load(selectorPackDir)
+		case PC:
+			t, err = dpc.Load(selectorPackDir)
+		}
+	case Naver:
+		switch collectType {
+		case Mobile:
+			t = nmobile.New()
+		case PC:
+			t = npc.New()
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	} else if t == nil {
+		return nil, fmt.Errorf("collector source %s, type %s is not supported", collectSource, collectType)
+	}
+
+	return t, nil
+}
+
+// newBrowser launches a browser of the profile of collectType, which records or replays its requests when
+// RecordDir or ReplayDir is set. The browser and the launcher are closed when it fails midway.
+func newBrowser(collectType string, option Option) (*rod.Browser, types.Profile, *replay.Session, error) {
 	var profile types.Profile
 	var browser *rod.Browser
 
-	log.Println("new collector with option", option)
-
 	l := launcher.New()
 	if option.UserDataDir != "" {
 		l.UserDataDir(option.UserDataDir)
@@ -55,6 +164,17 @@ func NewCollector(collectSource, collectType string, option Option) (types.Colle
 		}
 	}
 
+	switch collectType {
+	case PC:
+		browser = rod.New()
+		profile = types.PC()
+	case Mobile:
+		browser = rod.New().DefaultDevice(devices.IPhone6or7or8)
+		profile = types.Mobile()
+	default:
+		return nil, profile, nil, fmt.Errorf("collector type %s is not supported", collectType)
+	}
+
 	url, err := l.
 		Headless(option.Headless).
 		Devtools(false).
@@ -67,41 +187,30 @@ func NewCollector(collectSource, collectType string, option Option) (types.Colle
 		Launch()
 
 	if err != nil {
-		return nil, err
-	}
-
-	switch collectType {
-	case PC:
-		browser = rod.New()
-		profile = types.PC()
-	case Mobile:
-		browser = rod.New().DefaultDevice(devices.IPhone6or7or8)
-		profile = types.Mobile()
-	default:
-		return nil, fmt.Errorf("collector type %s is not supported", collectType)
+		return nil, profile, nil, err
 	}
 
 	err = browser.
 		ControlURL(url).
 		Connect()
 
-	switch collectSource {
-	case Daum:
-		switch collectType {
-		case Mobile:
-			t = dmobile.New()
-		case PC:
-			t = dpc.New()
-		}
-		c, err = daum.NewPortal(browser, profile, t, option.SavePath, cache.NewLargeCache())
-	case Naver:
+	if err != nil {
+		l.Kill()
+		return nil, profile, nil, err
+	}
+
+	var hijack *replay.Session
+	if option.ReplayDir != "" {
+		hijack, err = replay.Replay(browser, option.ReplayDir)
+	} else if option.RecordDir != "" {
+		hijack, err = replay.Record(browser, option.RecordDir)
 	}
 
 	if err != nil {
-		return nil, err
-	} else if c == nil {
-		return nil, fmt.Errorf("collector source %s, type %s is not supported", collectSource, collectType)
+		_ = browser.Close()
+		l.Kill()
+		return nil, profile, nil, err
 	}
 
-	return c, nil
+	return browser, profile, hijack, nil
 }
@@ -1,6 +1,9 @@
 package daum
 
 import (
+	"context"
+	"time"
+
 	rt "github.com/darimuri/go-lib/rodtemplate"
 	"github.com/go-rod/rod"
 
@@ -20,17 +23,17 @@ type Collector struct {
 	*adaptor.Adaptor
 }
 
-func (c *Collector) Top() {
-	c.Open(topNewsURL)
+func (c *Collector) Top(ctx context.Context) {
+	c.Open(ctx, topNewsURL)
 }
 
-func (c *Collector) NewsHome() {
-	c.Open(newsHomeURL)
+func (c *Collector) NewsHome(ctx context.Context) {
+	c.Open(ctx, newsHomeURL)
 }
 
-func NewPortal(browser *rod.Browser, profile types.Profile, collector types.TypedCollector, dumpRoot string, endCache cache.Cache) (types.Collector, error) {
+func NewPortal(browser *rod.Browser, profile types.Profile, collector types.TypedCollector, dumpRoot string, endCache cache.Cache, endCacheTTL time.Duration, comments types.CommentOption) (types.Collector, error) {
 	s := &Collector{
-		Adaptor: &adaptor.Adaptor{BrowserTemplate: rt.NewBrowserTemplate(browser), Profile: profile, Collector: collector, DumpRoot: dumpRoot, Cache: endCache},
+		Adaptor: &adaptor.Adaptor{BrowserTemplate: rt.NewBrowserTemplate(browser), Profile: profile, Collector: collector, DumpRoot: dumpRoot, Cache: endCache, CacheTTL: endCacheTTL, Comments: comments},
 	}
 
 	return s, nil
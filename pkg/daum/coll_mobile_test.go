@@ -9,6 +9,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/darimuri/coll-news/pkg/daum/mobile"
+	"github.com/darimuri/coll-news/pkg/replay"
 	"github.com/darimuri/coll-news/pkg/test"
 	"github.com/darimuri/coll-news/pkg/types"
 )
@@ -16,6 +17,7 @@ import (
 var _ = Describe("daum news mobile", func() {
 	var browser *rod.Browser
 	var cut types.Collector
+	var hijack *replay.Session
 
 	BeforeEach(func() {
 		url, err := launcher.New().
@@ -34,20 +36,26 @@ var _ = Describe("daum news mobile", func() {
 			Connect()
 		Expect(err).Should(BeNil())
 
-		cut, err = NewPortal(browser, types.Mobile(), mobile.New(), "../../test/daum/mobile", endCache)
+		hijack, err = test.Hijack(browser, "daum/mobile")
+		Expect(err).Should(BeNil())
+
+		cut, err = NewPortal(browser, types.Mobile(), mobile.New(), "../../test/daum/mobile", endCache, endCacheTTL, types.CommentOption{})
 		Expect(err).Should(BeNil())
 	})
 
 	AfterEach(func() {
+		if hijack != nil {
+			Expect(hijack.Close()).Should(BeNil())
+		}
 		cut.Cleanup()
 		_ = browser.Close()
 	})
 
 	Context("collect", func() {
 		It("top news", func() {
-			cut.Top()
+			cut.Top(ctx)
 
-			newsList, err := cut.GetTopNewsList()
+			newsList, err := cut.GetTopNewsList(ctx)
 			Expect(err).Should(BeNil())
 			Expect(newsList).ShouldNot(BeNil())
 			Expect(newsList).ShouldNot(BeEmpty())
@@ -62,7 +70,7 @@ var _ = Describe("daum news mobile", func() {
 				Expect(n.FullScreenShot).ShouldNot(BeEmpty())
 				//Expect(n.TabScreenShot).ShouldNot(BeEmpty())
 
-				err = cut.GetNewsEnd(&n)
+				err = cut.GetNewsEnd(ctx, &n)
 				_, typedError := err.(adaptor.TypedError)
 				if false == typedError {
 					Expect(err).Should(BeNil(), "error getting top news end %v", n)
@@ -71,9 +79,9 @@ var _ = Describe("daum news mobile", func() {
 		})
 
 		It("news home news", func() {
-			cut.NewsHome()
+			cut.NewsHome(ctx)
 
-			newsList, err := cut.GetNewsHomeNewsList()
+			newsList, err := cut.GetNewsHomeNewsList(ctx)
 			Expect(err).Should(BeNil())
 			Expect(newsList).ShouldNot(BeNil())
 			Expect(newsList).ShouldNot(BeEmpty())
@@ -88,7 +96,7 @@ var _ = Describe("daum news mobile", func() {
 				Expect(n.FullScreenShot).ShouldNot(BeEmpty())
 				//Expect(n.TabScreenShot).ShouldNot(BeEmpty())
 
-				err = cut.GetNewsEnd(&n)
+				err = cut.GetNewsEnd(ctx, &n)
 				_, typedError := err.(adaptor.TypedError)
 				if false == typedError {
 					Expect(err).Should(BeNil(), "error getting top news end %v", n)
@@ -97,9 +105,9 @@ var _ = Describe("daum news mobile", func() {
 		})
 
 		It("new end causes no error failed to find content block from url", func() {
-			cut.Top()
+			cut.Top(ctx)
 			n := types.News{URL: "https://tv.kakao.com/m/channel/3443434/cliplink/423110964"}
-			err := cut.GetNewsEnd(&n)
+			err := cut.GetNewsEnd(ctx, &n)
 			_, typedError := err.(adaptor.TypedError)
 			if false == typedError {
 				Expect(err).Should(BeNil(), "error getting top news end %v", n)
@@ -9,6 +9,7 @@ import (
 	"github.com/darimuri/coll-news/pkg/adaptor"
 
 	"github.com/darimuri/coll-news/pkg/daum/pc"
+	"github.com/darimuri/coll-news/pkg/replay"
 	"github.com/darimuri/coll-news/pkg/test"
 	"github.com/darimuri/coll-news/pkg/types"
 )
@@ -16,6 +17,7 @@ import (
 var _ = Describe("daum news pc", func() {
 	var browser *rod.Browser
 	var cut types.Collector
+	var hijack *replay.Session
 
 	BeforeEach(func() {
 		url, err := launcher.New().
@@ -34,20 +36,26 @@ var _ = Describe("daum news pc", func() {
 			Connect()
 		Expect(err).Should(BeNil())
 
-		cut, err = NewPortal(browser, types.PC(), pc.New(), "../../test/daum/pc", endCache)
+		hijack, err = test.Hijack(browser, "daum/pc")
+		Expect(err).Should(BeNil())
+
+		cut, err = NewPortal(browser, types.PC(), pc.New(), "../../test/daum/pc", endCache, endCacheTTL, types.CommentOption{})
 		Expect(err).Should(BeNil())
 	})
 
 	AfterEach(func() {
+		if hijack != nil {
+			Expect(hijack.Close()).Should(BeNil())
+		}
 		cut.Cleanup()
 		_ = browser.Close()
 	})
 
 	Context("collect", func() {
 		It("top news", func() {
-			cut.Top()
+			cut.Top(ctx)
 
-			newsList, err := cut.GetTopNewsList()
+			newsList, err := cut.GetTopNewsList(ctx)
 			Expect(err).Should(BeNil())
 			Expect(newsList).ShouldNot(BeNil())
 			Expect(newsList).ShouldNot(BeEmpty())
@@ -62,7 +70,7 @@ var _ = Describe("daum news pc", func() {
 				Expect(n.FullScreenShot).ShouldNot(BeEmpty())
 				Expect(n.TabScreenShot).ShouldNot(BeEmpty())
 
-				err = cut.GetNewsEnd(&n)
+				err = cut.GetNewsEnd(ctx, &n)
 				_, typedError := err.(adaptor.TypedError)
 				if false == typedError {
 					Expect(err).Should(BeNil(), "error getting top news end %v", n)
@@ -71,9 +79,9 @@ var _ = Describe("daum news pc", func() {
 		})
 
 		It("news home news", func() {
-			cut.NewsHome()
+			cut.NewsHome(ctx)
 
-			newsList, err := cut.GetNewsHomeNewsList()
+			newsList, err := cut.GetNewsHomeNewsList(ctx)
 			Expect(err).Should(BeNil())
 			Expect(newsList).ShouldNot(BeNil())
 			Expect(newsList).ShouldNot(BeEmpty())
@@ -88,7 +96,7 @@ var _ = Describe("daum news pc", func() {
 				Expect(n.FullScreenShot).ShouldNot(BeEmpty())
 				Expect(n.TabScreenShot).ShouldNot(BeEmpty())
 
-				err = cut.GetNewsEnd(&n)
+				err = cut.GetNewsEnd(ctx, &n)
 				_, typedError := err.(adaptor.TypedError)
 				if false == typedError {
 					Expect(err).Should(BeNil(), "error getting top news end %v", n)
@@ -97,9 +105,9 @@ var _ = Describe("daum news pc", func() {
 		})
 
 		It("new end ModifiedAt is correct for author is 고수정", func() {
-			cut.Top()
+			cut.Top(ctx)
 			n := types.News{URL: "https://news.v.daum.net/v/20211016040021618"}
-			err := cut.GetNewsEnd(&n)
+			err := cut.GetNewsEnd(ctx, &n)
 			_, typedError := err.(adaptor.TypedError)
 			if false == typedError {
 				Expect(err).Should(BeNil(), "error getting top news end %v", n)
@@ -109,9 +117,9 @@ var _ = Describe("daum news pc", func() {
 		})
 
 		It("new end causes no error div[id=kakaoContent] block is missing", func() {
-			cut.Top()
+			cut.Top(ctx)
 			n := types.News{URL: "https://content.v.daum.net/v/kWGY0DyI9E"}
-			err := cut.GetNewsEnd(&n)
+			err := cut.GetNewsEnd(ctx, &n)
 			_, typedError := err.(adaptor.TypedError)
 			if false == typedError {
 				Expect(err).Should(BeNil(), "error getting top news end %v", n)
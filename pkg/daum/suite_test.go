@@ -1,7 +1,9 @@
 package daum
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/darimuri/coll-news/pkg/cache"
 	. "github.com/onsi/ginkgo"
@@ -15,6 +17,10 @@ func TestSuite(t *testing.T) {
 
 var endCache cache.Cache
 
+var ctx = context.Background()
+
+const endCacheTTL = time.Minute * 3
+
 var _ = BeforeSuite(func() {
 	endCache = cache.NewLargeCache()
 })
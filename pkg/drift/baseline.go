@@ -0,0 +1,249 @@
+package drift
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	// Window is the number of recent runs a baseline keeps.
+	Window = 10
+	// MinRuns is the number of runs a baseline needs before it is compared with.
+	MinRuns = 3
+	// DropRatio of the baseline items or less is a sharp drop of a block.
+	DropRatio = 0.5
+
+	BaselineFile = "baseline.json"
+
+	ReasonDisappeared = "disappeared"
+	ReasonDropped     = "dropped"
+	ReasonNewBranch   = "new_branch"
+)
+
+// Drift is a block or a selection point of a run which differs from the baseline.
+type Drift struct {
+	Block    string  `json:"block,omitempty"`
+	Point    string  `json:"point,omitempty"`
+	Branch   string  `json:"branch,omitempty"`
+	Reason   string  `json:"reason"`
+	Items    int     `json:"items"`
+	Baseline float64 `json:"baseline"`
+	Sample   string  `json:"sample,omitempty"`
+}
+
+// Key is the block, or the point and branch of d.
+func (d Drift) Key() string {
+	if d.Block != "" {
+		return d.Block
+	}
+
+	return d.Point + "=" + d.Branch
+}
+
+func (d Drift) String() string {
+	switch d.Reason {
+	case ReasonNewBranch:
+		return fmt.Sprintf("%s matched %s %d times, which no run of the baseline did", d.Point, d.Branch, d.Items)
+	default:
+		return fmt.Sprintf("block %s %s with %d items against %.1f of the baseline", d.Block, d.Reason, d.Items, d.Baseline)
+	}
+}
+
+// Baseline is the recent runs a run is compared with.
+type Baseline struct {
+	Runs []Run `json:"runs"`
+}
+
+// LoadBaseline reads the baseline of path, which is empty when it is not saved yet.
+func LoadBaseline(path string) (*Baseline, error) {
+	b := &Baseline{}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return b, nil
+		}
+		return nil, err
+	}
+
+	if err = json.Unmarshal(data, b); err != nil {
+		return nil, fmt.Errorf("baseline %s is broken: %w", path, err)
+	}
+
+	return b, nil
+}
+
+func (b *Baseline) Save(path string) error {
+	data, err := json.Marshal(b)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), os.FileMode(0700)); err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err = ioutil.WriteFile(tmp, data, os.FileMode(0644)); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
+
+// Add rolls run into the baseline, dropping runs older than Window. Runs which recorded nothing,
+// e.g. of collectors without selector packs, are not added.
+func (b *Baseline) Add(run Run) {
+	if run.empty() {
+		return
+	}
+
+	b.Runs = append(b.Runs, run)
+	if len(b.Runs) > Window {
+		b.Runs = b.Runs[len(b.Runs)-Window:]
+	}
+}
+
+// Check compares run with the baseline. Blocks gone or dropped to DropRatio of the median of the
+// baseline, and branches no run of the baseline matched are drifts.
+func (b *Baseline) Check(run Run) []Drift {
+	drifts := make([]Drift, 0)
+
+	if len(b.Runs) < MinRuns || run.empty() {
+		return drifts
+	}
+
+	blocks := make(map[string]bool)
+	branches := make(map[string]map[string]bool)
+	for _, r := range b.Runs {
+		for block := range r.Blocks {
+			blocks[block] = true
+		}
+
+		for point, matched := range r.Matches {
+			if _, ok := branches[point]; false == ok {
+				branches[point] = make(map[string]bool)
+			}
+			for branch := range matched {
+				branches[point][branch] = true
+			}
+		}
+	}
+
+	for _, block := range sortedKeys(blocks) {
+		counts := make([]int, 0, len(b.Runs))
+		for _, r := range b.Runs {
+			counts = append(counts, r.Blocks[block])
+		}
+
+		base := median(counts)
+		items := run.Blocks[block]
+
+		switch {
+		case base > 0 && items == 0:
+			drifts = append(drifts, Drift{Block: block, Reason: ReasonDisappeared, Items: items, Baseline: base})
+		case base > 0 && float64(items) <= base*DropRatio:
+			drifts = append(drifts, Drift{Block: block, Reason: ReasonDropped, Items: items, Baseline: base})
+		}
+	}
+
+	points := make(map[string]bool)
+	for point := range run.Matches {
+		points[point] = true
+	}
+
+	for _, point := range sortedKeys(points) {
+		matched := make(map[string]bool)
+		for branch := range run.Matches[point] {
+			matched[branch] = true
+		}
+
+		for _, branch := range sortedKeys(matched) {
+			if false == branches[point][branch] {
+				drifts = append(drifts, Drift{Point: point, Branch: branch, Reason: ReasonNewBranch, Items: run.Matches[point][branch]})
+			}
+		}
+	}
+
+	return drifts
+}
+
+// WriteReport writes drifts of the run of prefix to dir as <prefix>.drift.json, with html samples of the
+// list pages of drifted blocks and of the pages which met new branches.
+func WriteReport(dir, prefix string, drifts []Drift, r *Recorder) error {
+	if err := os.MkdirAll(dir, os.FileMode(0700)); err != nil {
+		return err
+	}
+
+	for i, d := range drifts {
+		sample := filepath.Join(dir, fmt.Sprintf("%s.%02d.%s.html", prefix, i, fileName(d.Key())))
+
+		var html []byte
+		var err error
+
+		if d.Block != "" {
+			page := r.page(strings.SplitN(d.Block, "/", 2)[0])
+			if page == "" {
+				continue
+			}
+			if html, err = ioutil.ReadFile(page); err != nil {
+				return err
+			}
+		} else {
+			if html = []byte(r.sample(d.Key())); len(html) == 0 {
+				continue
+			}
+		}
+
+		if err = ioutil.WriteFile(sample, html, os.FileMode(0644)); err != nil {
+			return err
+		}
+
+		drifts[i].Sample = sample
+	}
+
+	data, err := json.MarshalIndent(drifts, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filepath.Join(dir, prefix+".drift.json"), data, os.FileMode(0644))
+}
+
+func median(values []int) float64 {
+	sorted := append([]int(nil), values...)
+	sort.Ints(sorted)
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return float64(sorted[mid])
+	}
+
+	return float64(sorted[mid-1]+sorted[mid]) / 2
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func fileName(key string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, key)
+}
@@ -0,0 +1,136 @@
+package drift
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func recorded(headline, popular int, content string) Run {
+	r := NewRecorder()
+	r.Block("Home/headline", headline)
+	r.Block("Home/popular", popular)
+	r.Match(PointEndContent, content)
+	r.Match(PointEndKind, "article")
+
+	return r.Run()
+}
+
+var _ = Describe("baseline", func() {
+	var b *Baseline
+
+	BeforeEach(func() {
+		b = &Baseline{}
+		for i := 0; i < MinRuns; i++ {
+			b.Add(recorded(20+i, 10, "div[id=daumContent]"))
+		}
+	})
+
+	It("needs MinRuns runs to compare", func() {
+		Expect((&Baseline{Runs: b.Runs[:MinRuns-1]}).Check(recorded(0, 0, BranchNone))).Should(BeEmpty())
+	})
+
+	It("has no drift for a usual run", func() {
+		Expect(b.Check(recorded(19, 8, "div[id=daumContent]"))).Should(BeEmpty())
+	})
+
+	It("finds disappeared and dropped blocks", func() {
+		drifts := b.Check(recorded(0, 5, "div[id=daumContent]"))
+
+		Expect(drifts).Should(ConsistOf(
+			Drift{Block: "Home/headline", Reason: ReasonDisappeared, Items: 0, Baseline: 21},
+			Drift{Block: "Home/popular", Reason: ReasonDropped, Items: 5, Baseline: 10},
+		))
+	})
+
+	It("finds branches no run of the baseline matched", func() {
+		drifts := b.Check(recorded(21, 10, BranchNone))
+
+		Expect(drifts).Should(ConsistOf(Drift{Point: PointEndContent, Branch: BranchNone, Reason: ReasonNewBranch, Items: 1}))
+		Expect(drifts[0].Key()).Should(Equal("end/content=none"))
+	})
+
+	It("keeps the recent Window runs except empty ones", func() {
+		b.Add(Run{})
+		Expect(b.Runs).Should(HaveLen(MinRuns))
+
+		for i := 0; i < Window; i++ {
+			b.Add(recorded(0, 10, "main[id=kakaoContent]"))
+		}
+		Expect(b.Runs).Should(HaveLen(Window))
+		Expect(b.Check(recorded(0, 10, "main[id=kakaoContent]"))).Should(BeEmpty())
+	})
+
+	Context("with files", func() {
+		var dir string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "drift")
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			Expect(os.RemoveAll(dir)).Should(Succeed())
+		})
+
+		It("saves and loads the baseline", func() {
+			path := filepath.Join(dir, "drift", BaselineFile)
+
+			empty, err := LoadBaseline(path)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(empty.Runs).Should(BeEmpty())
+
+			Expect(b.Save(path)).Should(Succeed())
+
+			loaded, err := LoadBaseline(path)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(loaded).Should(Equal(b))
+		})
+
+		It("writes a report with html samples", func() {
+			page := filepath.Join(dir, "home.html")
+			Expect(ioutil.WriteFile(page, []byte("<html>home</html>"), 0644)).Should(Succeed())
+
+			r := NewRecorder()
+			r.Page("Home", page)
+			r.Sample(PointEndContent, BranchNone, func() (string, error) { return "<html>end</html>", nil })
+			r.Sample(PointEndContent, BranchNone, func() (string, error) { return "", errors.New("sampled once") })
+
+			drifts := []Drift{
+				{Block: "Home/headline", Reason: ReasonDisappeared},
+				{Point: PointEndContent, Branch: BranchNone, Reason: ReasonNewBranch},
+				{Point: PointEndKind, Branch: "video", Reason: ReasonNewBranch},
+			}
+			Expect(WriteReport(dir, "20211016-040021", drifts, r)).Should(Succeed())
+
+			Expect(ioutil.ReadFile(drifts[0].Sample)).Should(Equal([]byte("<html>home</html>")))
+			Expect(ioutil.ReadFile(drifts[1].Sample)).Should(Equal([]byte("<html>end</html>")))
+			Expect(drifts[2].Sample).Should(BeEmpty())
+			Expect(filepath.Join(dir, "20211016-040021.drift.json")).Should(BeAnExistingFile())
+		})
+	})
+})
+
+var _ = Describe("recorder", func() {
+	It("records nothing without a recorder in the context", func() {
+		r := FromContext(context.Background())
+		Expect(r).Should(BeNil())
+
+		r.Match(PointEndKind, "article")
+		r.Block("Top/media", 3)
+		Expect(r.Run().empty()).Should(BeTrue())
+	})
+
+	It("is taken from the context", func() {
+		r := NewRecorder()
+		FromContext(WithRecorder(context.Background(), r)).Match(PointEndKind, "video")
+
+		Expect(r.Run().Matches).Should(Equal(map[string]map[string]int{PointEndKind: {"video": 1}}))
+	})
+})
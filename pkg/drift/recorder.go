@@ -0,0 +1,165 @@
+package drift
+
+import (
+	"context"
+	"sync"
+)
+
+const (
+	// PointEndContent is where the content block of an end is matched.
+	PointEndContent = "end/content"
+	// PointEndKind is which kind(article, video, ...) an end matched, or why it did not.
+	PointEndKind = "end/kind"
+	// PointEndComments is whether comments of an end were shown, or which panel of them was missing.
+	PointEndComments = "end/comments"
+
+	// BranchNone is recorded when none of the selectors of a point matched.
+	BranchNone = "none"
+)
+
+type contextKey struct{}
+
+// Run is what was recorded while collecting a run: how many times each branch of a selection point
+// matched and how many items each block of the list pages had.
+type Run struct {
+	Matches map[string]map[string]int `json:"matches"`
+	Blocks  map[string]int            `json:"blocks"`
+}
+
+func (r Run) empty() bool {
+	return len(r.Matches) == 0 && len(r.Blocks) == 0
+}
+
+// Recorder records a run. A nil Recorder records nothing, so collectors record whether anyone
+// listens or not.
+type Recorder struct {
+	mu      sync.Mutex
+	run     Run
+	pages   map[string]string
+	samples map[string]string
+}
+
+func NewRecorder() *Recorder {
+	return &Recorder{
+		run:     Run{Matches: make(map[string]map[string]int), Blocks: make(map[string]int)},
+		pages:   make(map[string]string),
+		samples: make(map[string]string),
+	}
+}
+
+// WithRecorder makes collections under ctx record to r.
+func WithRecorder(ctx context.Context, r *Recorder) context.Context {
+	return context.WithValue(ctx, contextKey{}, r)
+}
+
+// FromContext is the recorder of ctx, nil if there is none.
+func FromContext(ctx context.Context) *Recorder {
+	r, _ := ctx.Value(contextKey{}).(*Recorder)
+	return r
+}
+
+// Match records that branch, e.g. a selector of fallbacks, matched at point.
+func (r *Recorder) Match(point, branch string) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.run.Matches[point]; false == ok {
+		r.run.Matches[point] = make(map[string]int)
+	}
+	r.run.Matches[point][branch]++
+}
+
+// Block adds items read from block, zero items records the block is missing.
+func (r *Recorder) Block(block string, items int) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.run.Blocks[block] += items
+}
+
+// Page records the html dump of the list page of location, e.g. top or home.
+func (r *Recorder) Page(location, htmlFile string) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.pages[location] = htmlFile
+}
+
+// Sample keeps html of the first page met branch at point. html is called only for the first one.
+func (r *Recorder) Sample(point, branch string, html func() (string, error)) {
+	if r == nil {
+		return
+	}
+
+	key := point + "=" + branch
+
+	r.mu.Lock()
+	_, sampled := r.samples[key]
+	if false == sampled {
+		r.samples[key] = ""
+	}
+	r.mu.Unlock()
+
+	if sampled {
+		return
+	}
+
+	content, err := html()
+	if err != nil {
+		return
+	}
+
+	r.mu.Lock()
+	r.samples[key] = content
+	r.mu.Unlock()
+}
+
+// Run is a copy of what is recorded so far.
+func (r *Recorder) Run() Run {
+	run := Run{Matches: make(map[string]map[string]int), Blocks: make(map[string]int)}
+	if r == nil {
+		return run
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for point, branches := range r.run.Matches {
+		run.Matches[point] = make(map[string]int)
+		for branch, count := range branches {
+			run.Matches[point][branch] = count
+		}
+	}
+
+	for block, items := range r.run.Blocks {
+		run.Blocks[block] = items
+	}
+
+	return run
+}
+
+func (r *Recorder) page(location string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.pages[location]
+}
+
+func (r *Recorder) sample(key string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.samples[key]
+}
@@ -0,0 +1,170 @@
+package headline
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/darimuri/coll-news/pkg/types"
+	"github.com/darimuri/coll-news/pkg/util"
+)
+
+// Dir is the directory of headline histories under the save path of a source and type.
+const Dir = "headlines"
+
+const (
+	KindList = "list"
+	KindEnd  = "end"
+)
+
+// Title is a distinct title of an article, listed by the portal or written in its end, and the runs it was seen.
+type Title struct {
+	Kind      string    `json:"kind"`
+	Text      string    `json:"text"`
+	FirstSeen time.Time `json:"first_seen"`
+	LastSeen  time.Time `json:"last_seen"`
+}
+
+// History is the titles of an article by its url, the first seen first.
+type History struct {
+	URL    string  `json:"url"`
+	Titles []Title `json:"titles"`
+}
+
+// See records kind of title was seen at. Runs may be seen out of order, when histories are made of old dumps.
+func (h *History) See(kind, text string, at time.Time) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return
+	}
+
+	for i := range h.Titles {
+		t := &h.Titles[i]
+		if t.Kind != kind || t.Text != text {
+			continue
+		}
+
+		if at.Before(t.FirstSeen) {
+			t.FirstSeen = at
+		}
+		if at.After(t.LastSeen) {
+			t.LastSeen = at
+		}
+
+		return
+	}
+
+	h.Titles = append(h.Titles, Title{Kind: kind, Text: text, FirstSeen: at, LastSeen: at})
+
+	sort.SliceStable(h.Titles, func(i, j int) bool {
+		return h.Titles[i].FirstSeen.Before(h.Titles[j].FirstSeen)
+	})
+}
+
+// SeeNews records the list title and the end title of n seen at.
+func (h *History) SeeNews(n types.News, at time.Time) {
+	h.See(KindList, n.Title, at)
+
+	if n.End != nil {
+		h.See(KindEnd, n.End.Title, at)
+	}
+}
+
+// File is the history file of the article of url under dir, named by a hash of its normalized url.
+func File(dir, url string) (string, error) {
+	hash, err := util.URLHash(url)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, hash+".json"), nil
+}
+
+// Load reads the history of the article of url under dir, which is empty when it is not seen yet.
+func Load(dir, url string) (*History, error) {
+	path, err := File(dir, url)
+	if err != nil {
+		return nil, err
+	}
+
+	h := &History{URL: url, Titles: make([]Title, 0)}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return h, nil
+		}
+		return nil, err
+	}
+
+	if err = json.Unmarshal(data, h); err != nil {
+		return nil, fmt.Errorf("headline history %s is broken: %w", path, err)
+	}
+
+	return h, nil
+}
+
+// Save writes h under dir.
+func (h *History) Save(dir string) error {
+	path, err := File(dir, h.URL)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(h)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(dir, os.FileMode(0700)); err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err = ioutil.WriteFile(tmp, data, os.FileMode(0644)); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
+
+// Track records titles of news of a run started at to the histories of their articles under dir.
+// News of the same article listed in several places are recorded to its history at once.
+func Track(dir string, news []types.News, at time.Time) error {
+	keys := make([]string, 0)
+	byKey := make(map[string][]types.News)
+
+	for _, n := range news {
+		key, err := util.NormalizeURL(n.URL)
+		if err != nil {
+			continue
+		}
+
+		if _, ok := byKey[key]; false == ok {
+			keys = append(keys, key)
+		}
+		byKey[key] = append(byKey[key], n)
+	}
+
+	for _, key := range keys {
+		h, err := Load(dir, byKey[key][0].URL)
+		if err != nil {
+			return err
+		}
+
+		for _, n := range byKey[key] {
+			h.SeeNews(n, at)
+		}
+
+		if err = h.Save(dir); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package headline
+
+import (
+	"io/ioutil"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/darimuri/coll-news/pkg/types"
+)
+
+var _ = Describe("headline", func() {
+	var dir string
+	started := time.Date(2021, 10, 16, 4, 0, 0, 0, time.UTC)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "headline")
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).Should(Succeed())
+	})
+
+	It("tracks distinct list and end titles across runs", func() {
+		end := &types.End{Title: "end title"}
+		first := []types.News{
+			{URL: "https://v.daum.net/v/1?f=o", Title: "list title", End: end},
+			{URL: "https://v.daum.net/v/1", Title: "list title ", End: end},
+		}
+		Expect(Track(dir, first, started)).Should(Succeed())
+
+		second := []types.News{{URL: "https://v.daum.net/v/1", Title: "rewritten title", End: end}}
+		Expect(Track(dir, second, started.Add(time.Hour))).Should(Succeed())
+
+		h, err := Load(dir, "https://v.daum.net/v/1?f=m")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(h.Titles).Should(HaveLen(3))
+
+		Expect(h.Titles[0].Text).Should(Equal("list title"))
+		Expect(h.Titles[0].LastSeen.Equal(started)).Should(BeTrue())
+
+		Expect(h.Titles[1].Kind).Should(Equal(KindEnd))
+		Expect(h.Titles[1].LastSeen.Equal(started.Add(time.Hour))).Should(BeTrue())
+
+		Expect(h.Titles[2].Text).Should(Equal("rewritten title"))
+		Expect(h.Titles[2].FirstSeen.Equal(started.Add(time.Hour))).Should(BeTrue())
+	})
+
+	It("keeps the earliest first seen of runs seen out of order", func() {
+		h := &History{URL: "https://v.daum.net/v/1"}
+		h.See(KindList, "title", started.Add(time.Hour))
+		h.See(KindList, "title", started)
+
+		Expect(h.Titles).Should(HaveLen(1))
+		Expect(h.Titles[0].FirstSeen.Equal(started)).Should(BeTrue())
+		Expect(h.Titles[0].LastSeen.Equal(started.Add(time.Hour))).Should(BeTrue())
+	})
+})
@@ -0,0 +1,134 @@
+package history
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ContextLines is the number of unchanged lines kept around changes of a hunk.
+const ContextLines = 3
+
+type line struct {
+	kind byte
+	a, b int
+	text string
+}
+
+// Unified is the unified diff of lines of a to b named from and to, empty when they have the same lines.
+func Unified(from, to, a, b string) string {
+	lines := diffLines(splitLines(a), splitLines(b))
+
+	changed := make([]int, 0)
+	for i, l := range lines {
+		if l.kind != ' ' {
+			changed = append(changed, i)
+		}
+	}
+
+	if len(changed) == 0 {
+		return ""
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString("--- " + from + "\n")
+	sb.WriteString("+++ " + to + "\n")
+
+	for i := 0; i < len(changed); {
+		start := max(0, changed[i]-ContextLines)
+		end := min(len(lines), changed[i]+ContextLines+1)
+
+		for i++; i < len(changed) && changed[i]-ContextLines <= end; i++ {
+			end = min(len(lines), changed[i]+ContextLines+1)
+		}
+
+		writeHunk(&sb, lines[start:end])
+	}
+
+	return sb.String()
+}
+
+func writeHunk(sb *strings.Builder, hunk []line) {
+	numA, numB := 0, 0
+	for _, l := range hunk {
+		if l.kind != '+' {
+			numA++
+		}
+		if l.kind != '-' {
+			numB++
+		}
+	}
+
+	startA, startB := hunk[0].a, hunk[0].b
+	if numA > 0 {
+		startA++
+	}
+	if numB > 0 {
+		startB++
+	}
+
+	sb.WriteString(fmt.Sprintf("@@ -%d,%d +%d,%d @@\n", startA, numA, startB, numB))
+	for _, l := range hunk {
+		sb.WriteByte(l.kind)
+		sb.WriteString(l.text)
+		sb.WriteByte('\n')
+	}
+}
+
+// diffLines walks the longest common subsequence of a and b, removals of a line before additions.
+func diffLines(a, b []string) []line {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	lines := make([]line, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, line{kind: ' ', a: i, b: j, text: a[i]})
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, line{kind: '-', a: i, b: j, text: a[i]})
+			i++
+		default:
+			lines = append(lines, line{kind: '+', a: i, b: j, text: b[j]})
+			j++
+		}
+	}
+
+	return lines
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,186 @@
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/darimuri/coll-news/pkg/types"
+	"github.com/darimuri/coll-news/pkg/util"
+)
+
+// Dir is the directory of article versions under the save path of a source and type.
+const Dir = "versions"
+
+// Version is an end of an article as it was collected. Versions after the first keep diffs from the one before.
+type Version struct {
+	Number      int    `json:"number"`
+	URL         string `json:"url"`
+	Title       string `json:"title"`
+	Text        string `json:"text"`
+	ModifiedAt  string `json:"modified_at,omitempty"`
+	CollectedAt string `json:"collected_at"`
+	TitleDiff   string `json:"title_diff,omitempty"`
+	TextDiff    string `json:"text_diff,omitempty"`
+}
+
+// ArticleDir is the directory of versions of the article of url under dir, named by a hash of its normalized url.
+func ArticleDir(dir, url string) (string, error) {
+	hash, err := util.URLHash(url)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, hash), nil
+}
+
+// Record stores the end of n as a new version of its article under dir when it is the first one, or when its
+// modified time, title or text differs from the latest version. It returns the stored version, nil when nothing changed.
+func Record(dir string, n types.News) (*Version, error) {
+	if n.End == nil {
+		return nil, nil
+	}
+
+	articleDir, err := ArticleDir(dir, n.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	latest, err := Latest(articleDir)
+	if err != nil {
+		return nil, err
+	}
+
+	v := Version{
+		Number:      1,
+		URL:         n.URL,
+		Title:       n.End.Title,
+		Text:        n.End.Text,
+		ModifiedAt:  n.End.ModifiedAt,
+		CollectedAt: n.End.CollectedAt,
+	}
+
+	if latest != nil {
+		if latest.ModifiedAt == v.ModifiedAt && latest.Title == v.Title && latest.Text == v.Text {
+			return nil, nil
+		}
+
+		v.Number = latest.Number + 1
+		v.TitleDiff = Unified(versionName(latest.Number, "title"), versionName(v.Number, "title"), latest.Title, v.Title)
+		v.TextDiff = Unified(versionName(latest.Number, "text"), versionName(v.Number, "text"), latest.Text, v.Text)
+	}
+
+	if err = save(articleDir, v); err != nil {
+		return nil, err
+	}
+
+	return &v, nil
+}
+
+// Latest reads the version of the highest number in articleDir, nil when none is stored yet.
+func Latest(articleDir string) (*Version, error) {
+	numbers, err := versionNumbers(articleDir)
+	if err != nil || len(numbers) == 0 {
+		return nil, err
+	}
+
+	return read(articleDir, numbers[len(numbers)-1])
+}
+
+// Versions reads all versions stored in articleDir, the first one first.
+func Versions(articleDir string) ([]Version, error) {
+	numbers, err := versionNumbers(articleDir)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]Version, 0, len(numbers))
+	for _, number := range numbers {
+		v, err := read(articleDir, number)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, *v)
+	}
+
+	return versions, nil
+}
+
+func versionNumbers(articleDir string) ([]int, error) {
+	files, err := ioutil.ReadDir(articleDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	numbers := make([]int, 0, len(files))
+	for _, f := range files {
+		if false == strings.HasSuffix(f.Name(), ".json") {
+			continue
+		}
+
+		number, err := strconv.Atoi(strings.TrimSuffix(f.Name(), ".json"))
+		if err != nil {
+			continue
+		}
+		numbers = append(numbers, number)
+	}
+
+	sort.Ints(numbers)
+
+	return numbers, nil
+}
+
+func read(articleDir string, number int) (*Version, error) {
+	path := filepath.Join(articleDir, fmt.Sprintf("%04d.json", number))
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &Version{}
+	if err = json.Unmarshal(data, v); err != nil {
+		return nil, fmt.Errorf("version %s is broken: %w", path, err)
+	}
+
+	return v, nil
+}
+
+// save writes v as <number>.json, and its diffs as <number>.diff to read without tools.
+func save(articleDir string, v Version) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(articleDir, os.FileMode(0700)); err != nil {
+		return err
+	}
+
+	prefix := filepath.Join(articleDir, fmt.Sprintf("%04d", v.Number))
+
+	if v.TitleDiff != "" || v.TextDiff != "" {
+		if err = ioutil.WriteFile(prefix+".diff", []byte(v.TitleDiff+v.TextDiff), os.FileMode(0644)); err != nil {
+			return err
+		}
+	}
+
+	tmp := prefix + ".json.tmp"
+	if err = ioutil.WriteFile(tmp, data, os.FileMode(0644)); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, prefix+".json")
+}
+
+func versionName(number int, part string) string {
+	return fmt.Sprintf("v%d/%s", number, part)
+}
@@ -0,0 +1,81 @@
+package history
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/darimuri/coll-news/pkg/types"
+)
+
+func edition(title, text, modifiedAt string) types.News {
+	return types.News{
+		URL: "https://v.daum.net/v/1?f=o",
+		End: &types.End{Title: title, Text: text, ModifiedAt: modifiedAt, CollectedAt: "2021-10-16 13:00:00"},
+	}
+}
+
+var _ = Describe("history", func() {
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "history")
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).Should(Succeed())
+	})
+
+	It("diffs lines with context", func() {
+		a := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n14"
+		b := "1\n2\n3\n4\nfive\n6\n7\n8\n9\n10\n11\n12\n13"
+
+		Expect(Unified("a", "b", a, a)).Should(BeEmpty())
+		Expect(Unified("a", "b", a, b)).Should(Equal("--- a\n+++ b\n" +
+			"@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+five\n 6\n 7\n 8\n" +
+			"@@ -11,4 +11,3 @@\n 11\n 12\n 13\n-14\n"))
+		Expect(Unified("a", "b", "", "new")).Should(Equal("--- a\n+++ b\n@@ -0,0 +1,1 @@\n+new\n"))
+	})
+
+	It("stores versions of an article when it is edited", func() {
+		v, err := Record(dir, edition("title", "first\nsecond", "2021-10-16 12:00"))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(v.Number).Should(Equal(1))
+		Expect(v.TextDiff).Should(BeEmpty())
+
+		v, err = Record(dir, edition("title", "first\nsecond", "2021-10-16 12:00"))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(v).Should(BeNil())
+
+		v, err = Record(dir, edition("edited title", "first\n2nd", "2021-10-16 12:30"))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(v.Number).Should(Equal(2))
+		Expect(v.TitleDiff).Should(ContainSubstring("-title\n+edited title\n"))
+		Expect(v.TextDiff).Should(ContainSubstring(" first\n-second\n+2nd\n"))
+
+		articleDir, err := ArticleDir(dir, "https://v.daum.net/v/1")
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(filepath.Join(articleDir, "0002.diff")).Should(BeARegularFile())
+
+		versions, err := Versions(articleDir)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(versions).Should(HaveLen(2))
+		Expect(versions[0].Text).Should(Equal("first\nsecond"))
+		Expect(versions[1].Title).Should(Equal("edited title"))
+	})
+	It("stores a version when only the title is edited", func() {
+		_, err := Record(dir, edition("title", "text", "2021-10-16 12:00"))
+		Expect(err).ShouldNot(HaveOccurred())
+
+		v, err := Record(dir, edition("edited title", "text", "2021-10-16 12:00"))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(v.Number).Should(Equal(2))
+		Expect(v.TitleDiff).Should(ContainSubstring("-title\n+edited title\n"))
+		Expect(v.TextDiff).Should(BeEmpty())
+	})
+})
@@ -0,0 +1,213 @@
+package metrics
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/darimuri/coll-news/pkg/adaptor"
+	"github.com/darimuri/coll-news/pkg/drift"
+	"github.com/darimuri/coll-news/pkg/types"
+)
+
+const (
+	namespace = "coll_news"
+
+	PhaseTop      = "top"
+	PhaseNewsHome = "news_home"
+	PhaseEnds     = "ends"
+	PhaseRevisit  = "revisit"
+
+	errorTyped = "typed"
+	errorOther = "other"
+)
+
+var (
+	runsStarted = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "runs_started_total",
+			Help:      "Number of collections started.",
+		},
+		[]string{"source", "type"},
+	)
+
+	runsSucceeded = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "runs_succeeded_total",
+			Help:      "Number of collections saved their lists.",
+		},
+		[]string{"source", "type"},
+	)
+
+	runsFailed = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "runs_failed_total",
+			Help:      "Number of collections stopped by an error.",
+		},
+		[]string{"source", "type"},
+	)
+
+	phaseDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "phase_duration_seconds",
+			Help:      "Duration of each phase(top/news_home/ends) of a collection.",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 12),
+		},
+		[]string{"source", "type", "phase"},
+	)
+
+	items = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "items",
+			Help:      "Number of news collected by the last collection per location and news page.",
+		},
+		[]string{"source", "type", "location", "news_page"},
+	)
+
+	endFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "end_failures_total",
+			Help:      "Number of news ends failed to parse by error type(typed/other).",
+		},
+		[]string{"source", "type", "error"},
+	)
+
+	selectorMatches = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "selector_matches_total",
+			Help:      "Number of times a branch(selector, end kind) matched at a selection point of selector packs.",
+		},
+		[]string{"source", "type", "point", "branch"},
+	)
+
+	layoutDrifts = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "layout_drifts_total",
+			Help:      "Number of blocks and selection points differed from the baseline of recent runs by reason(disappeared/dropped/new_branch).",
+		},
+		[]string{"source", "type", "key", "reason"},
+	)
+
+	lastSuccess = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "last_success_timestamp_seconds",
+			Help:      "Unix time of the last collection saved its lists.",
+		},
+		[]string{"source", "type"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(runsStarted, runsSucceeded, runsFailed, phaseDuration, items, endFailures, selectorMatches, layoutDrifts, lastSuccess)
+}
+
+// Collection records metrics of the collections of a source and type.
+type Collection struct {
+	source      string
+	collectType string
+
+	mu        sync.Mutex
+	itemPages map[types.Loc]map[int]bool
+}
+
+func NewCollection(source, collectType string) *Collection {
+	return &Collection{
+		source:      source,
+		collectType: collectType,
+		itemPages:   map[types.Loc]map[int]bool{types.Top: {}, types.Home: {}},
+	}
+}
+
+func (c *Collection) RunStarted() {
+	runsStarted.WithLabelValues(c.source, c.collectType).Inc()
+}
+
+func (c *Collection) RunFinished(err error) {
+	if err != nil {
+		runsFailed.WithLabelValues(c.source, c.collectType).Inc()
+		return
+	}
+
+	runsSucceeded.WithLabelValues(c.source, c.collectType).Inc()
+	lastSuccess.WithLabelValues(c.source, c.collectType).Set(float64(time.Now().Unix()))
+}
+
+// ObservePhase records the time passed since started for phase.
+func (c *Collection) ObservePhase(phase string, started time.Time) {
+	phaseDuration.WithLabelValues(c.source, c.collectType, phase).Observe(time.Since(started).Seconds())
+}
+
+// SetItems counts news per location and news page. Pages seen in earlier collections but missing now
+// are set to zero, so a block disappeared from the portal shows up instead of keeping its last count.
+func (c *Collection) SetItems(news []types.News) {
+	counts := make(map[types.Loc]map[int]int)
+	for _, n := range news {
+		if _, ok := counts[n.Location]; false == ok {
+			counts[n.Location] = make(map[int]int)
+		}
+		counts[n.Location][n.NewsPage]++
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for loc, pages := range counts {
+		if _, ok := c.itemPages[loc]; false == ok {
+			c.itemPages[loc] = make(map[int]bool)
+		}
+		for page := range pages {
+			c.itemPages[loc][page] = true
+		}
+	}
+
+	for loc, pages := range c.itemPages {
+		if len(pages) == 0 {
+			items.WithLabelValues(c.source, c.collectType, string(loc), "0").Set(0)
+			continue
+		}
+
+		for page := range pages {
+			items.WithLabelValues(c.source, c.collectType, string(loc), strconv.Itoa(page)).Set(float64(counts[loc][page]))
+		}
+	}
+}
+
+// EndFailed counts an end failed to parse, by whether the collector knows the cause.
+func (c *Collection) EndFailed(err error) {
+	errorType := errorOther
+
+	var typedErr adaptor.TypedError
+	if errors.As(err, &typedErr) {
+		errorType = errorTyped
+	}
+
+	endFailures.WithLabelValues(c.source, c.collectType, errorType).Inc()
+}
+
+// Matched counts branches matched at selection points in run.
+func (c *Collection) Matched(run drift.Run) {
+	for point, branches := range run.Matches {
+		for branch, count := range branches {
+			selectorMatches.WithLabelValues(c.source, c.collectType, point, branch).Add(float64(count))
+		}
+	}
+}
+
+// Drifted counts drifts of a run from the baseline.
+func (c *Collection) Drifted(drifts []drift.Drift) {
+	for _, d := range drifts {
+		layoutDrifts.WithLabelValues(c.source, c.collectType, d.Key(), d.Reason).Inc()
+	}
+}
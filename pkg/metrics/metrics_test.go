@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/darimuri/coll-news/pkg/adaptor"
+	"github.com/darimuri/coll-news/pkg/drift"
+	"github.com/darimuri/coll-news/pkg/types"
+)
+
+var _ = Describe("collection metrics", func() {
+	It("sets pages missing in the last collection to zero", func() {
+		c := NewCollection("test", "items")
+
+		c.SetItems([]types.News{
+			{Location: types.Top, NewsPage: 0},
+			{Location: types.Top, NewsPage: 1},
+			{Location: types.Top, NewsPage: 1},
+			{Location: types.Home, NewsPage: 0},
+		})
+
+		Expect(testutil.ToFloat64(items.WithLabelValues("test", "items", types.Top, "1"))).Should(Equal(2.0))
+		Expect(testutil.ToFloat64(items.WithLabelValues("test", "items", types.Home, "0"))).Should(Equal(1.0))
+
+		c.SetItems([]types.News{{Location: types.Top, NewsPage: 0}})
+
+		Expect(testutil.ToFloat64(items.WithLabelValues("test", "items", types.Top, "0"))).Should(Equal(1.0))
+		Expect(testutil.ToFloat64(items.WithLabelValues("test", "items", types.Top, "1"))).Should(Equal(0.0))
+		Expect(testutil.ToFloat64(items.WithLabelValues("test", "items", types.Home, "0"))).Should(Equal(0.0))
+	})
+
+	It("counts end failures by error type", func() {
+		c := NewCollection("test", "ends")
+
+		c.EndFailed(adaptor.CPBlockNotFound)
+		c.EndFailed(fmt.Errorf("wrapped %w", adaptor.NewTypedError("not a news end")))
+		c.EndFailed(errors.New("timeout"))
+
+		Expect(testutil.ToFloat64(endFailures.WithLabelValues("test", "ends", errorTyped))).Should(Equal(2.0))
+		Expect(testutil.ToFloat64(endFailures.WithLabelValues("test", "ends", errorOther))).Should(Equal(1.0))
+	})
+
+	It("counts selector matches and layout drifts", func() {
+		c := NewCollection("test", "drifts")
+
+		c.Matched(drift.Run{Matches: map[string]map[string]int{drift.PointEndKind: {"article": 3, drift.BranchNone: 1}}})
+		c.Drifted([]drift.Drift{{Block: "Top/media", Reason: drift.ReasonDisappeared}})
+
+		Expect(testutil.ToFloat64(selectorMatches.WithLabelValues("test", "drifts", drift.PointEndKind, "article"))).Should(Equal(3.0))
+		Expect(testutil.ToFloat64(layoutDrifts.WithLabelValues("test", "drifts", "Top/media", drift.ReasonDisappeared))).Should(Equal(1.0))
+	})
+
+	It("keeps the time of the last successful run", func() {
+		c := NewCollection("test", "runs")
+
+		c.RunStarted()
+		c.RunFinished(errors.New("failed"))
+		Expect(testutil.ToFloat64(lastSuccess.WithLabelValues("test", "runs"))).Should(Equal(0.0))
+
+		c.RunStarted()
+		c.RunFinished(nil)
+		Expect(testutil.ToFloat64(runsStarted.WithLabelValues("test", "runs"))).Should(Equal(2.0))
+		Expect(testutil.ToFloat64(runsFailed.WithLabelValues("test", "runs"))).Should(Equal(1.0))
+		Expect(testutil.ToFloat64(lastSuccess.WithLabelValues("test", "runs"))).ShouldNot(Equal(0.0))
+	})
+})
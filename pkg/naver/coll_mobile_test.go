@@ -1,6 +1,8 @@
 package naver
 
 import (
+	"errors"
+
 	"github.com/darimuri/coll-news/pkg/adaptor"
 	"github.com/go-rod/rod"
 	"github.com/go-rod/rod/lib/devices"
@@ -9,6 +11,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/darimuri/coll-news/pkg/naver/mobile"
+	"github.com/darimuri/coll-news/pkg/replay"
 	"github.com/darimuri/coll-news/pkg/test"
 	"github.com/darimuri/coll-news/pkg/types"
 )
@@ -16,6 +19,7 @@ import (
 var _ = Describe("naver news mobile", func() {
 	var browser *rod.Browser
 	var cut types.Collector
+	var hijack *replay.Session
 
 	BeforeEach(func() {
 		url, err := launcher.New().
@@ -34,20 +38,26 @@ var _ = Describe("naver news mobile", func() {
 			Connect()
 		Expect(err).Should(BeNil())
 
-		cut, err = NewPortal(browser, types.Mobile(), mobile.New(), "../../test/naver/mobile", endCache)
+		hijack, err = test.Hijack(browser, "naver/mobile")
+		Expect(err).Should(BeNil())
+
+		cut, err = NewPortal(browser, types.Mobile(), mobile.New(), "../../test/naver/mobile", endCache, endCacheTTL, types.CommentOption{})
 		Expect(err).Should(BeNil())
 	})
 
 	AfterEach(func() {
+		if hijack != nil {
+			Expect(hijack.Close()).Should(BeNil())
+		}
 		cut.Cleanup()
 		_ = browser.Close()
 	})
 
 	Context("collect", func() {
 		It("top news", func() {
-			cut.Top()
+			cut.Top(ctx)
 
-			newsList, err := cut.GetTopNewsList()
+			newsList, err := cut.GetTopNewsList(ctx)
 			Expect(err).Should(BeNil())
 			Expect(newsList).ShouldNot(BeNil())
 			Expect(newsList).ShouldNot(BeEmpty())
@@ -60,9 +70,9 @@ var _ = Describe("naver news mobile", func() {
 				Expect(n.Order).Should(BeNumerically(">=", 0))
 				Expect(n.FullHTML).ShouldNot(BeEmpty())
 				Expect(n.FullScreenShot).ShouldNot(BeEmpty())
-				//Expect(n.TabScreenShot).ShouldNot(BeEmpty())
+				Expect(n.TabScreenShot).ShouldNot(BeEmpty())
 
-				err = cut.GetNewsEnd(&n)
+				err = cut.GetNewsEnd(ctx, &n)
 				_, typedError := err.(adaptor.TypedError)
 				if false == typedError {
 					Expect(err).Should(BeNil(), "error getting top news end %v", n)
@@ -71,9 +81,9 @@ var _ = Describe("naver news mobile", func() {
 		})
 
 		It("news home news", func() {
-			cut.NewsHome()
+			cut.NewsHome(ctx)
 
-			newsList, err := cut.GetNewsHomeNewsList()
+			newsList, err := cut.GetNewsHomeNewsList(ctx)
 			Expect(err).Should(BeNil())
 			Expect(newsList).ShouldNot(BeNil())
 			Expect(newsList).ShouldNot(BeEmpty())
@@ -86,9 +96,9 @@ var _ = Describe("naver news mobile", func() {
 				Expect(n.Order).Should(BeNumerically(">=", 0))
 				Expect(n.FullHTML).ShouldNot(BeEmpty())
 				Expect(n.FullScreenShot).ShouldNot(BeEmpty())
-				//Expect(n.TabScreenShot).ShouldNot(BeEmpty())
+				Expect(n.TabScreenShot).ShouldNot(BeEmpty())
 
-				err = cut.GetNewsEnd(&n)
+				err = cut.GetNewsEnd(ctx, &n)
 				_, typedError := err.(adaptor.TypedError)
 				if false == typedError {
 					Expect(err).Should(BeNil(), "error getting top news end %v", n)
@@ -96,4 +106,89 @@ var _ = Describe("naver news mobile", func() {
 			}
 		})
 	})
+
+	Context("parse recordings", func() {
+		BeforeEach(func() {
+			if test.ReplayDir == "" {
+				Skip("recordings are parsed with TEST_REPLAY_DIR")
+			}
+		})
+
+		It("top news skips items without links", func() {
+			cut.Top(ctx)
+
+			newsList, err := cut.GetTopNewsList(ctx)
+			Expect(err).Should(BeNil())
+			Expect(newsList).Should(HaveLen(2))
+
+			Expect(newsList[0].URL).Should(Equal("https://n.news.naver.com/article/001/0012700001"))
+			Expect(newsList[0].Title).Should(Equal("반도체 수출 석 달째 증가"))
+			Expect(newsList[0].Publisher).Should(Equal("연합뉴스"))
+			Expect(newsList[0].Image).Should(Equal("https://imgnews.pstatic.net/image/001/2021/10/16/0012700001.jpg"))
+			Expect(newsList[0].SubOrder).Should(Equal(0))
+
+			Expect(newsList[1].URL).Should(Equal("https://n.news.naver.com/article/421/0005600002"))
+			Expect(newsList[1].Title).Should(Equal("청년 월세 지원 대상 넓힌다"), "title is the text of the link without a title span")
+			Expect(newsList[1].Publisher).Should(BeEmpty())
+			Expect(newsList[1].SubOrder).Should(Equal(2))
+
+			for _, n := range newsList {
+				Expect(n.Section).Should(Equal(types.Section{ID: "news_area", Label: "뉴스"}))
+				Expect(n.NewsPage).Should(Equal(1))
+				Expect(n.Order).Should(Equal(0))
+				Expect(n.TabScreenShot).Should(BeAnExistingFile())
+			}
+		})
+
+		It("news home bricks named by id, class and order", func() {
+			cut.NewsHome(ctx)
+
+			newsList, err := cut.GetNewsHomeNewsList(ctx)
+			Expect(err).Should(BeNil())
+			Expect(newsList).Should(HaveLen(4))
+
+			headline := types.Section{ID: "brick_headline", Label: "헤드라인뉴스"}
+			Expect(newsList[0].Section).Should(Equal(headline))
+			Expect(newsList[0].URL).Should(Equal("https://n.news.naver.com/article/001/0012700001"))
+			Expect(newsList[0].Publisher).Should(Equal("연합뉴스"))
+			Expect(newsList[0].Image).Should(Equal("https://imgnews.pstatic.net/image/001/2021/10/16/0012700001.jpg"))
+			Expect([]int{newsList[0].NewsPage, newsList[0].Order, newsList[0].SubOrder}).Should(Equal([]int{1, 0, 0}))
+
+			Expect(newsList[1].Section).Should(Equal(headline))
+			Expect(newsList[1].URL).Should(Equal("https://www.hankookilbo.com/News/Read/A2021101604000001"), "item shown by the more button is read")
+			Expect(newsList[1].Publisher).Should(Equal("한국일보"))
+			Expect([]int{newsList[1].NewsPage, newsList[1].Order, newsList[1].SubOrder}).Should(Equal([]int{1, 0, 2}), "item without a title is skipped")
+
+			Expect(newsList[2].Section).Should(Equal(types.Section{ID: "section_politics", Label: "정치"}))
+			Expect(newsList[2].Publisher).Should(Equal("뉴스1"))
+			Expect(newsList[2].NewsPage).Should(Equal(2), "brick without a list takes no page")
+
+			Expect(newsList[3].Section).Should(Equal(types.Section{ID: "main_brick_3"}))
+			Expect(newsList[3].URL).Should(Equal("https://sports.news.naver.com/news?oid=001&aid=0012700003"))
+			Expect(newsList[3].NewsPage).Should(Equal(3))
+
+			for _, n := range newsList {
+				Expect(n.TabScreenShot).Should(BeAnExistingFile())
+			}
+
+			err = cut.GetNewsEnd(ctx, &newsList[1])
+			Expect(errors.Is(err, adaptor.OutOfPortal)).Should(BeTrue())
+
+			err = cut.GetNewsEnd(ctx, &newsList[3])
+			_, typedError := err.(adaptor.TypedError)
+			Expect(typedError).Should(BeTrue(), "end of sports is not supported %v", err)
+		})
+
+		It("end skips reactions without a count", func() {
+			n := types.News{URL: "https://n.news.naver.com/article/001/0012700001"}
+			Expect(cut.GetNewsEnd(adaptor.WithRefresh(ctx), &n)).Should(BeNil())
+			Expect(n.End).ShouldNot(BeNil())
+
+			Expect(n.End.Title).Should(Equal("반도체 수출 석 달째 증가"))
+			Expect(n.End.Emotions).Should(Equal([]types.Emotion{
+				{Name: "좋아요", Count: 1024},
+				{Name: "슬퍼요", CountString: "많음"},
+			}))
+		})
+	})
 })
@@ -1,12 +1,17 @@
 package naver
 
 import (
+	"errors"
+	"time"
+
+	"github.com/darimuri/coll-news/pkg/adaptor"
 	"github.com/go-rod/rod"
 	"github.com/go-rod/rod/lib/launcher"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
 	"github.com/darimuri/coll-news/pkg/naver/pc"
+	"github.com/darimuri/coll-news/pkg/replay"
 	"github.com/darimuri/coll-news/pkg/test"
 	"github.com/darimuri/coll-news/pkg/types"
 )
@@ -14,6 +19,7 @@ import (
 var _ = Describe("naver news pc", func() {
 	var browser *rod.Browser
 	var cut types.Collector
+	var hijack *replay.Session
 
 	BeforeEach(func() {
 		url, err := launcher.New().
@@ -32,29 +38,52 @@ var _ = Describe("naver news pc", func() {
 			Connect()
 		Expect(err).Should(BeNil())
 
-		cut, err = NewPortal(browser, types.PC(), pc.New(), "../../test/naver/pc", endCache)
+		hijack, err = test.Hijack(browser, "naver/pc")
+		Expect(err).Should(BeNil())
+
+		cut, err = NewPortal(browser, types.PC(), pc.New(), "../../test/naver/pc", endCache, endCacheTTL, types.CommentOption{})
 		Expect(err).Should(BeNil())
 	})
 
 	AfterEach(func() {
+		if hijack != nil {
+			Expect(hijack.Close()).Should(BeNil())
+		}
 		cut.Cleanup()
 		_ = browser.Close()
 	})
 
 	Context("collect", func() {
 		It("top news", func() {
-			cut.Top()
+			cut.Top(ctx)
 
-			newsList, err := cut.GetTopNewsList()
+			newsList, err := cut.GetTopNewsList(ctx)
 			Expect(err).Should(BeNil())
 			Expect(newsList).ShouldNot(BeNil())
-			Expect(newsList).Should(BeEmpty())
+			Expect(newsList).ShouldNot(BeEmpty())
+
+			for idx := range newsList {
+				n := newsList[idx]
+				Expect(n.Title).ShouldNot(BeEmpty())
+				Expect(n.URL).ShouldNot(BeEmpty())
+				Expect(n.NewsPage).Should(BeNumerically(">", 0))
+				Expect(n.Order).Should(BeNumerically(">=", 0))
+				Expect(n.FullHTML).ShouldNot(BeEmpty())
+				Expect(n.FullScreenShot).ShouldNot(BeEmpty())
+				Expect(n.TabScreenShot).ShouldNot(BeEmpty())
+
+				err = cut.GetNewsEnd(ctx, &n)
+				_, typedError := err.(adaptor.TypedError)
+				if false == typedError {
+					Expect(err).Should(BeNil(), "error getting top news end %v", n)
+				}
+			}
 		})
 
-		FIt("news home news", func() {
-			cut.NewsHome()
+		It("news home news", func() {
+			cut.NewsHome(ctx)
 
-			newsList, err := cut.GetNewsHomeNewsList()
+			newsList, err := cut.GetNewsHomeNewsList(ctx)
 			Expect(err).Should(BeNil())
 			Expect(newsList).ShouldNot(BeNil())
 			Expect(newsList).ShouldNot(BeEmpty())
@@ -69,9 +98,131 @@ var _ = Describe("naver news pc", func() {
 				Expect(n.FullScreenShot).ShouldNot(BeEmpty())
 				Expect(n.TabScreenShot).ShouldNot(BeEmpty())
 
-				err = cut.GetNewsEnd(&n)
-				Expect(err).Should(BeNil(), "err getting new home news end %v", n)
+				err = cut.GetNewsEnd(ctx, &n)
+				_, typedError := err.(adaptor.TypedError)
+				if false == typedError {
+					Expect(err).Should(BeNil(), "err getting new home news end %v", n)
+				}
+			}
+		})
+	})
+
+	Context("parse recordings", func() {
+		BeforeEach(func() {
+			if test.ReplayDir == "" {
+				Skip("recordings are parsed with TEST_REPLAY_DIR")
 			}
 		})
+
+		It("newsstand issues and skips ends of press sites", func() {
+			cut.Top(ctx)
+
+			newsList, err := cut.GetTopNewsList(ctx)
+			Expect(err).Should(BeNil())
+			Expect(newsList).Should(HaveLen(2))
+
+			Expect(newsList[0].URL).Should(Equal("https://www.hankookilbo.com/News/Read/A2021101604000001"))
+			Expect(newsList[0].Title).Should(Equal("청년 월세 지원 대상 넓힌다"))
+			Expect(newsList[0].Publisher).Should(Equal("한국일보"))
+			Expect(newsList[1].URL).Should(Equal("https://n.news.naver.com/article/001/0012700001"))
+			Expect(newsList[1].Publisher).Should(Equal("연합뉴스"))
+
+			for idx, n := range newsList {
+				Expect(n.Section).Should(Equal(types.Section{ID: "newsstand_issue", Label: "뉴스스탠드"}))
+				Expect(n.NewsPage).Should(Equal(1))
+				Expect(n.Order).Should(Equal(idx))
+				Expect(n.TabScreenShot).Should(BeAnExistingFile())
+			}
+
+			err = cut.GetNewsEnd(ctx, &newsList[0])
+			Expect(errors.Is(err, adaptor.OutOfPortal)).Should(BeTrue())
+			Expect(newsList[0].End).Should(BeNil())
+		})
+
+		It("news home sections in order", func() {
+			cut.NewsHome(ctx)
+
+			newsList, err := cut.GetNewsHomeNewsList(ctx)
+			Expect(err).Should(BeNil())
+			Expect(newsList).Should(HaveLen(7))
+
+			headline := types.Section{ID: "today_main_news", Label: "헤드라인"}
+			Expect(newsList[0].Section).Should(Equal(headline))
+			Expect(newsList[0].URL).Should(Equal("https://n.news.naver.com/article/001/0012700001"))
+			Expect(newsList[0].Title).Should(Equal("반도체 수출 석 달째 증가"))
+			Expect(newsList[0].Image).Should(Equal("https://imgnews.pstatic.net/image/001/2021/10/16/0012700001.jpg"))
+			Expect([]int{newsList[0].NewsPage, newsList[0].Order, newsList[0].SubOrder}).Should(Equal([]int{1, 0, 0}))
+
+			Expect(newsList[1].Section).Should(Equal(headline))
+			Expect(newsList[1].Title).Should(Equal("청년 월세 지원 대상 넓힌다"))
+			Expect(newsList[1].Publisher).Should(Equal("뉴스1"))
+			Expect([]int{newsList[1].NewsPage, newsList[1].Order, newsList[1].SubOrder}).Should(Equal([]int{1, 1, 0}))
+
+			Expect(newsList[2].URL).Should(Equal("https://sports.news.naver.com/news?oid=001&aid=0012700003"))
+			Expect(newsList[2].Publisher).Should(BeEmpty())
+			Expect([]int{newsList[2].NewsPage, newsList[2].Order, newsList[2].SubOrder}).Should(Equal([]int{1, 1, 1}))
+
+			Expect(newsList[3].Section).Should(Equal(types.Section{ID: "section_politics", Label: "정치"}))
+			Expect(newsList[3].Publisher).Should(Equal("뉴스1"))
+			Expect(newsList[3].NewsPage).Should(Equal(2))
+
+			Expect(newsList[4].Section).Should(Equal(types.Section{ID: "section_economy", Label: "경제"}))
+			Expect(newsList[4].Publisher).Should(Equal("연합뉴스"))
+			Expect(newsList[4].NewsPage).Should(Equal(3))
+
+			ranking := types.Section{ID: "section_ranking", Label: "많이 본 뉴스"}
+			Expect(newsList[5].Section).Should(Equal(ranking))
+			Expect(newsList[5].Title).Should(Equal("반도체 수출 석 달째 증가"), "title of a ranking is taken from its title attribute")
+			Expect([]int{newsList[5].NewsPage, newsList[5].Order, newsList[5].SubOrder}).Should(Equal([]int{8, 0, 0}))
+			Expect(newsList[6].Title).Should(Equal("청년 월세 지원 대상 넓힌다"))
+			Expect(newsList[6].SubOrder).Should(Equal(1))
+
+			for _, n := range newsList {
+				Expect(n.TabScreenShot).Should(BeAnExistingFile())
+			}
+
+			err = cut.GetNewsEnd(ctx, &newsList[2])
+			_, typedError := err.(adaptor.TypedError)
+			Expect(typedError).Should(BeTrue(), "end of sports is not supported %v", err)
+		})
+
+		It("end with head, categorize and linked reactions", func() {
+			n := types.News{URL: "https://n.news.naver.com/article/001/0012700001"}
+			Expect(cut.GetNewsEnd(adaptor.WithRefresh(ctx), &n)).Should(BeNil())
+			Expect(n.End).ShouldNot(BeNil())
+
+			Expect(n.End.Provider).Should(Equal("연합뉴스"))
+			Expect(n.End.Title).Should(Equal("반도체 수출 석 달째 증가"))
+			Expect(n.End.Author).Should(Equal("홍길동 기자"))
+			Expect(n.End.Category).Should(Equal("경제"))
+			Expect(n.End.PostedAt).Should(Equal(time.Date(2021, 10, 16, 4, 0, 21, 0, time.Local).Format(types.DataDateTimeFormat)))
+			Expect(n.End.ModifiedAt).Should(Equal(time.Date(2021, 10, 16, 5, 10, 0, 0, time.Local).Format(types.DataDateTimeFormat)))
+			Expect(n.End.NumComment).Should(BeNumerically("==", 1234))
+
+			Expect(n.End.Text).Should(HavePrefix("반도체 수출이 석 달째 늘었다."))
+			Expect(n.End.Text).Should(HaveSuffix("반도체 수출이 전년보다 20% 늘었다고 밝혔다."))
+			Expect(n.End.Text).ShouldNot(ContainSubstring("부산항 신선대부두"), "captions of images are removed from text")
+			Expect(n.End.Images).Should(Equal([]string{"https://imgnews.pstatic.net/image/001/2021/10/16/0012700001_001.jpg"}))
+			Expect(n.End.HTML).ShouldNot(BeEmpty())
+
+			Expect(n.End.Emotions).Should(Equal([]types.Emotion{
+				{Name: "좋아요", Count: 1024},
+				{Name: "슬퍼요", CountString: "많음"},
+			}), "reaction without a count is skipped")
+		})
+
+		It("end with byline and reaction module", func() {
+			n := types.News{URL: "https://n.news.naver.com/article/421/0005600002"}
+			Expect(cut.GetNewsEnd(adaptor.WithRefresh(ctx), &n)).Should(BeNil())
+			Expect(n.End).ShouldNot(BeNil())
+
+			Expect(n.End.Provider).Should(Equal("뉴스1"))
+			Expect(n.End.Author).Should(Equal("김철수 기자 (chulsoo@news1.kr)"))
+			Expect(n.End.Category).Should(BeEmpty())
+			Expect(n.End.ModifiedAt).Should(BeEmpty())
+			Expect(n.End.NumComment).Should(BeNumerically("==", 0))
+			Expect(n.End.Images).Should(Equal([]string{"https://imgnews.pstatic.net/image/421/2021/10/16/0005600002_001.jpg"}))
+			Expect(n.End.Emotions).Should(Equal([]types.Emotion{{Name: "좋아요", Count: 7}}))
+		})
 	})
 })
@@ -0,0 +1,108 @@
+package end
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	rt "github.com/darimuri/go-lib/rodtemplate"
+
+	"github.com/darimuri/coll-news/pkg/types"
+	"github.com/darimuri/coll-news/pkg/util"
+)
+
+const (
+	commentModuleSelector = "div[id=cbox_module]"
+	commentSelector       = "ul.u_cbox_list > li.u_cbox_comment"
+	commentMoreSelector   = "a.u_cbox_btn_more"
+)
+
+var commentSortSelectors = map[string]string{
+	types.CommentSortRecommended: "a.u_cbox_select[data-param=favorite]",
+	types.CommentSortLatest:      "a.u_cbox_select[data-param=new]",
+}
+
+// GetComments reads up to option.Limit comments of the end of n in option.Sort, the comment module of pc and mobile
+// pages is the same.
+func GetComments(ctx context.Context, p *rt.PageTemplate, n *types.News, option types.CommentOption) error {
+	if n.End == nil || false == p.Has(commentModuleSelector) {
+		return nil
+	}
+
+	moduleBlock := p.El(commentModuleSelector)
+	p.ScrollTo(moduleBlock)
+	p.WaitRepaint()
+
+	if option.Sort != "" {
+		sortSelector, ok := commentSortSelectors[option.Sort]
+		if false == ok {
+			return fmt.Errorf("comment sort %s is not supported", option.Sort)
+		}
+
+		if true == moduleBlock.Has(sortSelector) {
+			moduleBlock.El(sortSelector).MustClick()
+			p.WaitRepaint()
+		}
+	}
+
+	items := moduleBlock.Els(commentSelector)
+	for ctx.Err() == nil && len(items) < option.Limit && moduleBlock.Has(commentMoreSelector) {
+		more := moduleBlock.El(commentMoreSelector)
+		if false == more.MustVisible() {
+			break
+		}
+
+		more.MustClick()
+		p.WaitRepaint()
+
+		shown := len(items)
+		if items = moduleBlock.Els(commentSelector); len(items) == shown {
+			break
+		}
+	}
+
+	n.End.Comments = make([]types.Comment, 0)
+	for _, li := range items {
+		if len(n.End.Comments) == option.Limit {
+			break
+		}
+
+		// deleted comments and the ones hidden by the reporting have no contents
+		if false == li.Has("span.u_cbox_contents") {
+			continue
+		}
+
+		c := types.Comment{
+			Text: strings.TrimSpace(li.El("span.u_cbox_contents").MustText()),
+		}
+
+		if true == li.Has("span.u_cbox_nick") {
+			c.AuthorHash = util.HashAuthor(li.El("span.u_cbox_nick").MustText())
+		}
+
+		if true == li.Has("span.u_cbox_date") {
+			c.PostedAt = util.EmptyIfNilString(li.El("span.u_cbox_date").MustAttribute("data-value"))
+		}
+
+		c.Likes = commentCount(li, "em.u_cbox_cnt_recomm")
+		c.Dislikes = commentCount(li, "em.u_cbox_cnt_unrecomm")
+		c.Replies = commentCount(li, "span.u_cbox_reply_cnt")
+
+		n.End.Comments = append(n.End.Comments, c)
+	}
+
+	return nil
+}
+
+func commentCount(li *rt.ElementTemplate, selector string) uint64 {
+	if false == li.Has(selector) {
+		return 0
+	}
+
+	count, err := util.ParseCount(li.El(selector).MustText())
+	if err != nil {
+		return 0
+	}
+
+	return count
+}
@@ -0,0 +1,148 @@
+package end
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+
+	rt "github.com/darimuri/go-lib/rodtemplate"
+
+	"github.com/darimuri/coll-news/pkg/adaptor"
+	"github.com/darimuri/coll-news/pkg/types"
+	"github.com/darimuri/coll-news/pkg/util"
+)
+
+const contentSelector = "div[id=ct]"
+
+// GetNewsEnd reads the end of n, which pc and mobile pages of naver news render the same.
+func GetNewsEnd(ctx context.Context, p *rt.PageTemplate, n *types.News) error {
+	// newsstand of top links articles of press sites, whose ends are not read
+	if u, err := url.Parse(n.URL); err != nil || false == isNaver(u.Hostname()) {
+		return adaptor.OutOfPortal
+	}
+
+	if false == p.Has(contentSelector) {
+		if p.Has("div[id=content] > div.end_ct") {
+			return adaptor.NewTypedError("sports and entertainment end is not supported content block")
+		}
+		return fmt.Errorf("failed to find content block from url %s", n.URL)
+	}
+
+	contentBlock := p.SelectOrPanic(contentSelector)
+
+	headSelector := "div.media_end_head"
+	if false == contentBlock.Has(headSelector) {
+		log.Printf("head block %s is missing in %s\n", headSelector, n.URL)
+		return nil
+	}
+
+	headBlock := contentBlock.SelectOrPanic(headSelector)
+
+	n.End = &types.End{}
+
+	if true == contentBlock.Has("em.media_end_categorize_item") {
+		n.End.Category = contentBlock.El("em.media_end_categorize_item").MustText()
+	}
+
+	logoSelector := "a.media_end_head_top_logo"
+	if true == headBlock.Has(logoSelector) {
+		n.End.Provider = util.ImgALT(headBlock.El(logoSelector))
+		if n.End.Provider == "" {
+			n.End.Provider = util.ImgAltTryFromHTML(headBlock.El(logoSelector))
+		}
+	}
+
+	n.End.Title = strings.TrimSpace(headBlock.SelectOrPanic("h2.media_end_head_headline").MustText())
+
+	journalistSelector := "em.media_end_head_journalist_name"
+	if true == headBlock.Has(journalistSelector) {
+		n.End.Author = strings.TrimSpace(headBlock.El(journalistSelector).MustText())
+	} else if true == contentBlock.Has("span.byline_s") {
+		n.End.Author = strings.TrimSpace(contentBlock.El("span.byline_s").MustText())
+	} else {
+		n.End.Author = "NotFound"
+	}
+
+	postedSelector := "span._ARTICLE_DATE_TIME"
+	if true == headBlock.Has(postedSelector) {
+		n.End.PostedAt = util.EmptyIfNilString(headBlock.El(postedSelector).MustAttribute("data-date-time"))
+	}
+
+	modifiedSelector := "span._ARTICLE_MODIFY_DATE_TIME"
+	if true == headBlock.Has(modifiedSelector) {
+		n.End.ModifiedAt = util.EmptyIfNilString(headBlock.El(modifiedSelector).MustAttribute("data-modify-date-time"))
+	}
+
+	commentSelector := "span.media_end_head_cmtcount_button"
+	if true == headBlock.Has(commentSelector) {
+		if count, err := util.ParseCount(headBlock.El(commentSelector).MustText()); err == nil {
+			n.End.NumComment = count
+		}
+	}
+
+	bodySelector := "div[id=dic_area]"
+	if false == contentBlock.Has(bodySelector) {
+		return fmt.Errorf("failed to collect new end for %s", n.URL)
+	}
+
+	bodyBlock := contentBlock.El(bodySelector)
+	n.End.Text = bodyBlock.MustText()
+
+	if true == bodyBlock.Has("em.img_desc") {
+		for _, desc := range bodyBlock.Els("em.img_desc") {
+			n.End.Text = strings.Replace(n.End.Text, desc.MustText(), "", 1)
+		}
+		n.End.Text = strings.TrimSpace(n.End.Text)
+	}
+
+	n.End.HTML = p.El("html").MustHTML()
+
+	n.End.Images = make([]string, 0)
+	for _, img := range bodyBlock.Els("img") {
+		src := util.EmptyIfNilString(img.MustAttribute("data-src"))
+		if src == "" {
+			src = util.EmptyIfNilString(img.MustAttribute("src"))
+		}
+		n.End.Images = append(n.End.Images, src)
+	}
+
+	return parseReactions(contentBlock, n)
+}
+
+func parseReactions(contentBlock *rt.ElementTemplate, n *types.News) error {
+	reactionBoxSelector := "div.media_end_linked_reaction ul.u_likeit_layer"
+	if false == contentBlock.Has(reactionBoxSelector) {
+		reactionBoxSelector = "div._reactionModule ul.u_likeit_layer"
+	}
+
+	if false == contentBlock.Has(reactionBoxSelector) {
+		return nil
+	}
+
+	n.End.Emotions = make([]types.Emotion, 0)
+
+	reactionBox := contentBlock.El(reactionBoxSelector)
+	for _, li := range reactionBox.Els("li.u_likeit_list") {
+		reactionName := strings.TrimSpace(li.El("span.u_likeit_list_name").MustText())
+		reactionCount := strings.TrimSpace(li.El("span.u_likeit_list_count").MustText())
+
+		if reactionCount == "" {
+			log.Println("skip reaction collection of", reactionName, "for empty reactionCount string in", reactionBox.MustHTML())
+			continue
+		}
+
+		if count, err := util.ParseCount(reactionCount); err != nil {
+			n.End.Emotions = append(n.End.Emotions, types.Emotion{Name: reactionName, CountString: reactionCount})
+		} else {
+			n.End.Emotions = append(n.End.Emotions, types.Emotion{Name: reactionName, Count: int64(count)})
+		}
+	}
+
+	return nil
+}
+
+func isNaver(host string) bool {
+	return host == "naver.com" || strings.HasSuffix(host, ".naver.com")
+}
@@ -1,11 +1,31 @@
 package mobile
 
 import (
-	"github.com/darimuri/coll-news/pkg/types"
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
 	rt "github.com/darimuri/go-lib/rodtemplate"
+
+	"github.com/darimuri/coll-news/pkg/naver/end"
+	"github.com/darimuri/coll-news/pkg/types"
+	"github.com/darimuri/coll-news/pkg/util"
+)
+
+const (
+	topNewsSelector = "div[id=_MM_NEWS_AREA]"
+	brickSelector   = "div.main_brick"
+	moreSelector    = "a.sa_more_button"
+
+	brickTitleSelector = "h2"
 )
 
+var topNewsSection = types.Section{ID: "news_area", Label: "뉴스"}
+
 var _ types.TypedCollector = (*mobile)(nil)
+var _ types.CommentCollector = (*mobile)(nil)
 
 type mobile struct {
 }
@@ -14,18 +34,166 @@ func New() *mobile {
 	return &mobile{}
 }
 
-func (_ mobile) PrepareNewsHomeScreenShot(p *rt.PageTemplate) {
-	panic("implement me")
+func (_ mobile) PrepareNewsHomeScreenShot(ctx context.Context, p *rt.PageTemplate) {
+	if false == p.Has(brickSelector) {
+		return
+	}
+
+	for _, brickBlock := range p.Els(brickSelector) {
+		if false == brickBlock.Has(moreSelector) {
+			continue
+		}
+
+		p.ScrollTo(brickBlock)
+		p.WaitRepaint()
+
+		for ctx.Err() == nil && brickBlock.Has(moreSelector) && brickBlock.El(moreSelector).MustVisible() {
+			brickBlock.El(moreSelector).MustClick()
+			p.WaitRepaint()
+
+			time.Sleep(100 * time.Microsecond)
+		}
+	}
+}
+
+func (_ mobile) GetNewsHomeNewsList(ctx context.Context, p *rt.PageTemplate, dd types.DumpDirectory) ([]types.News, error) {
+	newsList := make([]types.News, 0)
+
+	if false == p.Has(brickSelector) {
+		return newsList, nil
+	}
+
+	pageNum := 1
+	for _, brickBlock := range p.Els(brickSelector) {
+		if false == brickBlock.Has("ul.sa_list") {
+			continue
+		}
+
+		p.ScrollTo(brickBlock)
+		p.WaitRepaint()
+		p.ScreenShot(brickBlock, dd.TabScreenShot(pageNum), 0)
+
+		section := types.Section{ID: brickID(brickBlock, pageNum)}
+		if true == brickBlock.Has(brickTitleSelector) {
+			section.Label = strings.TrimSpace(brickBlock.El(brickTitleSelector).MustText())
+		}
+
+		for idx, ul := range brickBlock.Els("ul.sa_list") {
+			for jdx, li := range ul.Els("li.sa_item") {
+				titleSelector := "a.sa_text_title"
+				if false == li.Has(titleSelector) {
+					log.Println("skip news home item without title", li.MustHTML())
+					continue
+				}
+
+				a := li.El(titleSelector)
+
+				publisher := ""
+				if li.Has("div.sa_text_press") {
+					publisher = li.El("div.sa_text_press").MustText()
+				}
+
+				n := types.News{
+					NewsPage:       pageNum,
+					Section:        section,
+					Order:          idx,
+					SubOrder:       jdx,
+					Publisher:      strings.TrimSpace(publisher),
+					Title:          strings.TrimSpace(a.MustText()),
+					URL:            util.EmptyIfNilString(a.MustAttribute("href")),
+					FullHTML:       dd.FullHTML(),
+					FullScreenShot: dd.FullScreenShot(),
+					TabScreenShot:  dd.TabScreenShot(pageNum),
+				}
+
+				if true == li.Has("div.sa_thumb") {
+					n.Image = util.ImgSrc(li.El("div.sa_thumb"))
+				}
+
+				newsList = append(newsList, n)
+			}
+		}
+
+		pageNum++
+	}
+
+	return newsList, nil
+}
+
+func (_ mobile) GetTopNewsList(ctx context.Context, p *rt.PageTemplate, dd types.DumpDirectory) ([]types.News, error) {
+	newsList := make([]types.News, 0)
+
+	if false == p.Has(topNewsSelector) {
+		return newsList, nil
+	}
+
+	pageNum := 1
+	topNewsBlock := p.El(topNewsSelector)
+
+	p.ScrollTo(topNewsBlock)
+	p.WaitRepaint()
+	p.ScreenShot(topNewsBlock, dd.TabScreenShot(pageNum), 0)
+
+	for idx, ul := range topNewsBlock.Els("ul.cnp_news_list") {
+		for jdx, li := range ul.Els("li") {
+			if false == li.Has("a") {
+				continue
+			}
+
+			a := li.El("a")
+
+			var title, publisher string
+			if li.Has("span.cnp_news_title") {
+				title = li.El("span.cnp_news_title").MustText()
+			} else {
+				title = a.MustText()
+			}
+
+			if li.Has("span.cnp_news_press") {
+				publisher = li.El("span.cnp_news_press").MustText()
+			}
+
+			news := types.News{
+				URL:            util.EmptyIfNilString(a.MustAttribute("href")),
+				Image:          util.ImgSrc(li),
+				Title:          strings.TrimSpace(title),
+				NewsPage:       pageNum,
+				Section:        topNewsSection,
+				Order:          idx,
+				SubOrder:       jdx,
+				Publisher:      strings.TrimSpace(publisher),
+				FullHTML:       dd.FullHTML(),
+				FullScreenShot: dd.FullScreenShot(),
+				TabScreenShot:  dd.TabScreenShot(pageNum),
+			}
+
+			newsList = append(newsList, news)
+		}
+	}
+
+	return newsList, nil
 }
 
-func (_ mobile) GetNewsHomeNewsList(p *rt.PageTemplate, dd types.DumpDirectory) ([]types.News, error) {
-	panic("implement me")
+func (_ mobile) GetNewsEnd(ctx context.Context, p *rt.PageTemplate, n *types.News) error {
+	return end.GetNewsEnd(ctx, p, n)
 }
 
-func (_ mobile) GetTopNewsList(p *rt.PageTemplate, dd types.DumpDirectory) ([]types.News, error) {
-	panic("implement me")
+func (_ mobile) GetComments(ctx context.Context, p *rt.PageTemplate, n *types.News, option types.CommentOption) error {
+	return end.GetComments(ctx, p, n, option)
 }
 
-func (_ mobile) GetNewsEnd(p *rt.PageTemplate, n *types.News) error {
-	panic("implement me")
+// brickID names a brick by its id or the first class of it other than main_brick, which stay the same while the
+// order of bricks changes. Bricks without both are named by their order.
+func brickID(brickBlock *rt.ElementTemplate, pageNum int) string {
+	if id := util.EmptyIfNilString(brickBlock.MustAttribute("id")); id != "" {
+		return id
+	}
+
+	for _, class := range strings.Fields(util.EmptyIfNilString(brickBlock.MustAttribute("class"))) {
+		if class != "main_brick" {
+			return strings.TrimLeft(class, "_")
+		}
+	}
+
+	return fmt.Sprintf("main_brick_%d", pageNum)
 }
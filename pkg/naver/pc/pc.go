@@ -1,48 +1,241 @@
 package pc
 
 import (
-	"github.com/darimuri/coll-news/pkg/types"
+	"context"
+	"fmt"
+	"strings"
+
 	rt "github.com/darimuri/go-lib/rodtemplate"
+
+	"github.com/darimuri/coll-news/pkg/naver/end"
+	"github.com/darimuri/coll-news/pkg/types"
+	"github.com/darimuri/coll-news/pkg/util"
+)
+
+const (
+	newsStandSelector = "div[id=NM_NEWSSTAND_HEADER]"
+)
+
+// newsSections are sections of news home in order, each is the block of div[id=<section id>].
+var newsSections = []types.Section{
+	{ID: "section_politics", Label: "정치"},
+	{ID: "section_economy", Label: "경제"},
+	{ID: "section_society", Label: "사회"},
+	{ID: "section_life", Label: "생활/문화"},
+	{ID: "section_world", Label: "세계"},
+	{ID: "section_it", Label: "IT/과학"},
+}
+
+var (
+	headlineSection  = types.Section{ID: "today_main_news", Label: "헤드라인"}
+	rankingSection   = types.Section{ID: "section_ranking", Label: "많이 본 뉴스"}
+	newsStandSection = types.Section{ID: "newsstand_issue", Label: "뉴스스탠드"}
 )
 
 var _ types.TypedCollector = (*pc)(nil)
+var _ types.CommentCollector = (*pc)(nil)
 
 type pc struct {
 }
 
-func (_ pc) PrepareNewsHomeScreenShot(p *rt.PageTemplate) {
+func (_ pc) PrepareNewsHomeScreenShot(ctx context.Context, p *rt.PageTemplate) {
 }
 
-func (_ pc) GetNewsHomeNewsList(p *rt.PageTemplate, dd types.DumpDirectory) ([]types.News, error) {
+func (_ pc) GetNewsHomeNewsList(ctx context.Context, p *rt.PageTemplate, dd types.DumpDirectory) ([]types.News, error) {
 	newsList := make([]types.News, 0)
 
-	//timelateBlock := p.SelectOrPanic("div.home_timelate")
-	// containerBlock := p.SelectOrPanic("div#container")
-	// mainBlock := containerBlock.SelectOrPanic("div#main_content")
-	// asideBlock := containerBlock.SelectOrPanic("div#main_aside")
+	containerSelector := "div[id=container]"
+	if false == p.Has(containerSelector) {
+		return newsList, nil
+	}
+
+	containerBlock := p.SelectOrPanic(containerSelector)
+	mainBlock := containerBlock.SelectOrPanic("div[id=main_content]")
+
+	pageNum := 1
+	headlineSelector := "div[id=today_main_news]"
+	if true == mainBlock.Has(headlineSelector) {
+		headlineBlock := mainBlock.SelectOrPanic(headlineSelector)
+		p.ScreenShot(headlineBlock, dd.TabScreenShot(pageNum), 0)
+
+		flickSelector := "div.hdline_flick"
+		if true == headlineBlock.Has(flickSelector) {
+			for idx, item := range headlineBlock.El(flickSelector).Els("div.hdline_flick_item") {
+				a := item.El("a")
+				news := types.News{
+					URL:            util.EmptyIfNilString(a.MustAttribute("href")),
+					Image:          util.ImgSrc(item),
+					Title:          strings.TrimSpace(item.El("p.hdline_flick_tit").MustText()),
+					NewsPage:       pageNum,
+					Section:        headlineSection,
+					Order:          0,
+					SubOrder:       idx,
+					FullHTML:       dd.FullHTML(),
+					FullScreenShot: dd.FullScreenShot(),
+					TabScreenShot:  dd.TabScreenShot(pageNum),
+				}
+
+				newsList = append(newsList, news)
+			}
+		}
+
+		articleListSelector := "ul.hdline_article_list"
+		if true == headlineBlock.Has(articleListSelector) {
+			for idx, li := range headlineBlock.El(articleListSelector).Els("li") {
+				a := li.El("div.hdline_article_tit > a")
+
+				publisher := ""
+				if li.Has("div.hdline_article_tit > div.writing") {
+					publisher = li.El("div.hdline_article_tit > div.writing").MustText()
+				}
+
+				news := types.News{
+					URL:            util.EmptyIfNilString(a.MustAttribute("href")),
+					Image:          util.ImgSrc(li),
+					Title:          strings.TrimSpace(a.MustText()),
+					NewsPage:       pageNum,
+					Section:        headlineSection,
+					Order:          1,
+					SubOrder:       idx,
+					FullHTML:       dd.FullHTML(),
+					FullScreenShot: dd.FullScreenShot(),
+					TabScreenShot:  dd.TabScreenShot(pageNum),
+					Publisher:      strings.TrimSpace(publisher),
+				}
+
+				newsList = append(newsList, news)
+			}
+		}
+	}
+
+	for _, section := range newsSections {
+		pageNum++
+		selector := fmt.Sprintf("div[id=%s]", section.ID)
+		if false == mainBlock.Has(selector) {
+			continue
+		}
+
+		sectionBlock := mainBlock.SelectOrPanic(selector)
+		p.ScreenShot(sectionBlock, dd.TabScreenShot(pageNum), 0)
+
+		for idx, ul := range sectionBlock.Els("div.com_list > div > ul") {
+			for jdx, li := range ul.Els("li") {
+				a := li.El("a")
+
+				publisher := ""
+				if li.Has("span.writing") {
+					publisher = li.El("span.writing").MustText()
+				}
+
+				news := types.News{
+					URL:            util.EmptyIfNilString(a.MustAttribute("href")),
+					Title:          strings.TrimSpace(a.MustText()),
+					NewsPage:       pageNum,
+					Section:        section,
+					Order:          idx,
+					SubOrder:       jdx,
+					FullHTML:       dd.FullHTML(),
+					FullScreenShot: dd.FullScreenShot(),
+					TabScreenShot:  dd.TabScreenShot(pageNum),
+					Publisher:      strings.TrimSpace(publisher),
+				}
+
+				newsList = append(newsList, news)
+			}
+		}
+	}
+
+	pageNum++
+
+	asideSelector := "div[id=main_aside]"
+	if true == containerBlock.Has(asideSelector) {
+		asideBlock := containerBlock.SelectOrPanic(asideSelector)
+
+		rankingSelector := "div.section_ranking"
+		if true == asideBlock.Has(rankingSelector) {
+			rankingBlock := asideBlock.El(rankingSelector)
+			p.ScreenShot(rankingBlock, dd.TabScreenShot(pageNum), 0)
+
+			for idx, ol := range rankingBlock.Els("ol.section_list_ranking") {
+				for jdx, li := range ol.Els("li") {
+					a := li.El("a")
+
+					title := util.EmptyIfNilString(a.MustAttribute("title"))
+					if title == "" {
+						title = a.MustText()
+					}
+
+					news := types.News{
+						URL:            util.EmptyIfNilString(a.MustAttribute("href")),
+						Title:          strings.TrimSpace(title),
+						NewsPage:       pageNum,
+						Section:        rankingSection,
+						Order:          idx,
+						SubOrder:       jdx,
+						FullHTML:       dd.FullHTML(),
+						FullScreenShot: dd.FullScreenShot(),
+						TabScreenShot:  dd.TabScreenShot(pageNum),
+					}
+
+					newsList = append(newsList, news)
+				}
+			}
+		}
+	}
+
+	return newsList, nil
+}
+
+func (_ pc) GetTopNewsList(ctx context.Context, p *rt.PageTemplate, dd types.DumpDirectory) ([]types.News, error) {
+	newsList := make([]types.News, 0)
+
+	if false == p.Has(newsStandSelector) {
+		return newsList, nil
+	}
+
+	pageNum := 1
+	newsStandBlock := p.El(newsStandSelector)
+
+	issueSelector := "div.group_issue"
+	if false == newsStandBlock.Has(issueSelector) {
+		return newsList, nil
+	}
+
+	issueBlock := newsStandBlock.El(issueSelector)
+	p.ScreenShot(issueBlock, dd.TabScreenShot(pageNum), 0)
+
+	for idx, item := range issueBlock.Els("div.issue_area") {
+		a := item.El("a.issue")
 
-	// headlineBlock := mainBlock.SelectOrPanic("div#today_main_news")
-	// politicsBlock := mainBlock.SelectOrPanic("div#section_politics")
-	// economyBlock := mainBlock.SelectOrPanic("div#section_economy")
-	// societyBlock := mainBlock.SelectOrPanic("div#section_society")
-	// lifeBlock := mainBlock.SelectOrPanic("div#section_life")
-	// worldBlock := mainBlock.SelectOrPanic("div#section_world")
-	// itBlock := mainBlock.SelectOrPanic("div#section_it")
+		publisher := ""
+		if item.Has("a.press") {
+			publisher = item.El("a.press").MustText()
+		}
 
-	// rankingBlock := asideBlock.SelectOrPanic("div.section")
+		news := types.News{
+			URL:            util.EmptyIfNilString(a.MustAttribute("href")),
+			Title:          strings.TrimSpace(a.MustText()),
+			NewsPage:       pageNum,
+			Section:        newsStandSection,
+			Order:          idx,
+			FullHTML:       dd.FullHTML(),
+			FullScreenShot: dd.FullScreenShot(),
+			TabScreenShot:  dd.TabScreenShot(pageNum),
+			Publisher:      strings.TrimSpace(publisher),
+		}
 
-	// flickBlock := headlineBlock.SelectOrPanic("div.hdline_flick")
-	// articleListBlock := headlineBlock.SelectOrPanic("ul.hdline_article_list")
+		newsList = append(newsList, news)
+	}
 
 	return newsList, nil
 }
 
-func (_ pc) GetTopNewsList(p *rt.PageTemplate, dd types.DumpDirectory) ([]types.News, error) {
-	return []types.News{}, nil
+func (_ pc) GetNewsEnd(ctx context.Context, p *rt.PageTemplate, n *types.News) error {
+	return end.GetNewsEnd(ctx, p, n)
 }
 
-func (_ pc) GetNewsEnd(p *rt.PageTemplate, n *types.News) error {
-	panic("implement me")
+func (_ pc) GetComments(ctx context.Context, p *rt.PageTemplate, n *types.News, option types.CommentOption) error {
+	return end.GetComments(ctx, p, n, option)
 }
 
 func New() *pc {
@@ -0,0 +1,18 @@
+package pack
+
+// builtin are packs shipped in the binary by collector name. A pack of the same name in the override
+// directory is taken instead, so a changed layout can be followed without a release.
+var builtin = map[string]string{
+	"daum/pc":     daumPC,
+	"daum/mobile": daumMobile,
+}
+
+// Names are names of the built-in packs.
+func Names() []string {
+	names := make([]string, 0, len(builtin))
+	for name := range builtin {
+		names = append(names, name)
+	}
+
+	return names
+}
@@ -0,0 +1,558 @@
+package pack
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	rt "github.com/darimuri/go-lib/rodtemplate"
+
+	"github.com/darimuri/coll-news/pkg/drift"
+	"github.com/darimuri/coll-news/pkg/selector"
+	"github.com/darimuri/coll-news/pkg/types"
+)
+
+var _ types.TypedCollector = (*Collector)(nil)
+
+// Collector collects news lists and ends with the selectors of a pack.
+type Collector struct {
+	pack *Pack
+}
+
+func NewCollector(p *Pack) *Collector {
+	return &Collector{pack: p}
+}
+
+// Open loads the pack of name, from overrideDir if it is there, and makes a collector of it.
+func Open(name, overrideDir string) (*Collector, error) {
+	p, err := Load(name, overrideDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCollector(p), nil
+}
+
+func (c *Collector) Pack() *Pack {
+	return c.pack
+}
+
+func (c *Collector) PrepareNewsHomeScreenShot(ctx context.Context, p *rt.PageTemplate) {
+	page := selector.NewPage(p, c.pack.Name, "")
+
+	for _, click := range c.pack.Home.Prepare {
+		var within scope = page
+		if click.Within != "" {
+			block, err := page.El(click.Within)
+			if err != nil {
+				continue
+			}
+
+			p.ScrollTo(block.ElementTemplate)
+			p.WaitRepaint()
+			within = block
+		}
+
+		for ctx.Err() == nil {
+			target, err := within.First(click.Select)
+			if err != nil || false == target.MustVisible() {
+				break
+			}
+
+			target.MustClick()
+			p.WaitRepaint()
+
+			time.Sleep(100 * time.Microsecond)
+		}
+	}
+}
+
+func (c *Collector) GetNewsHomeNewsList(ctx context.Context, p *rt.PageTemplate, dd types.DumpDirectory) ([]types.News, error) {
+	return c.list(ctx, p, dd, string(types.Home), c.pack.Home)
+}
+
+func (c *Collector) GetTopNewsList(ctx context.Context, p *rt.PageTemplate, dd types.DumpDirectory) ([]types.News, error) {
+	return c.list(ctx, p, dd, string(types.Top), c.pack.Top)
+}
+
+func (c *Collector) list(ctx context.Context, p *rt.PageTemplate, dd types.DumpDirectory, location string, l List) ([]types.News, error) {
+	page := selector.NewPage(p, c.pack.Name, dd.URL)
+
+	rec := drift.FromContext(ctx)
+	rec.Page(location, dd.FullHTML())
+
+	for _, sel := range l.Present {
+		if false == page.Has(sel) {
+			return make([]types.News, 0), nil
+		}
+	}
+
+	for _, sel := range l.Required {
+		if _, err := page.El(sel); err != nil {
+			return nil, err
+		}
+	}
+
+	w := &walker{ctx: ctx, p: p, page: page, dd: dd, rec: rec, location: location, news: make([]types.News, 0)}
+	for _, b := range l.Blocks {
+		if err := w.block(b); err != nil {
+			return w.news, err
+		}
+	}
+
+	return w.news, nil
+}
+
+// scope is where fields are selected from, a page or an element.
+type scope interface {
+	First(selectors ...string) (selector.Element, error)
+	Els(selector string) []selector.Element
+}
+
+// walker walks blocks of a list page in order, numbering news pages as it goes.
+type walker struct {
+	ctx      context.Context
+	p        *rt.PageTemplate
+	page     *selector.Page
+	dd       types.DumpDirectory
+	rec      *drift.Recorder
+	location string
+	news     []types.News
+	pageNum  int
+	// segment is of the tab being read, for blocks with demographic tabs.
+	segment segment
+	// tab is the label of the page being read, for blocks with pagers.
+	tab string
+}
+
+func (w *walker) block(b Block) error {
+	key := w.location + "/" + b.key()
+	before := len(w.news)
+	defer func() {
+		w.rec.Block(key, len(w.news)-before)
+	}()
+
+	sel := found(w.page.Has, b.Select)
+	w.rec.Match(key, branch(sel))
+
+	if b.Each {
+		if sel == "" {
+			return nil
+		}
+
+		for _, el := range w.page.Els(sel) {
+			if err := w.ctx.Err(); err != nil {
+				return err
+			}
+
+			if true == w.parse(b, el, w.pageNum+1, "", b.Section.of(el, types.Section{})) {
+				w.pageNum++
+			} else {
+				html, _ := el.HTML()
+				log.Println("failed to get news items from news block", el.Path(), html)
+			}
+		}
+
+		return nil
+	}
+
+	if b.Page != pageSame {
+		w.pageNum++
+	}
+
+	if sel == "" {
+		return nil
+	}
+
+	el, err := w.page.El(sel)
+	if err != nil {
+		return nil
+	}
+
+	sec := b.Section.of(el, types.Section{})
+
+	if b.Pager != nil {
+		return w.pager(b, el, sec)
+	}
+
+	if b.Tabs != "" {
+		return w.tabs(b, el, sec)
+	}
+
+	w.parse(b, el, w.pageNum, "", sec)
+
+	return nil
+}
+
+// pager reads a block for every page numbered in it, until it cycles back to the first page, the pages are read or
+// max clicks are made.
+func (w *walker) pager(b Block, el selector.Element, sec types.Section) error {
+	pg := b.Pager
+	defer func() {
+		w.tab = ""
+	}()
+
+	next, err := el.El(pg.Next)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[int]bool)
+	first, last := 0, 0
+
+	for i := 0; i < pg.Max; i++ {
+		if err = w.ctx.Err(); err != nil {
+			return err
+		}
+
+		el.MustWaitLoad()
+		el.MustWaitStable()
+		el.MustWaitVisible()
+
+		current, errPage := el.ElText(pg.Current)
+		if errPage != nil {
+			return errPage
+		}
+
+		pageNum, errPage := parsePage(current)
+		if errPage != nil {
+			return w.page.Errorf(el.Path()+" "+pg.Current, "page %s is not a number", current)
+		}
+
+		if pageNum == last {
+			// the page clicked to is not shown yet
+			w.p.WaitRepaint()
+			continue
+		}
+
+		if true == seen[pageNum] {
+			if pageNum != first {
+				log.Println("pages of", el.Path(), "cycled back to", pageNum, "instead of the first page", first)
+			}
+			return nil
+		}
+
+		if len(seen) == 0 {
+			first = pageNum
+		}
+		seen[pageNum] = true
+		last = pageNum
+
+		w.tab = ""
+		if pg.Label != nil {
+			if w.tab, errPage = fieldValue(el, *pg.Label); errPage != nil {
+				log.Println("tab label of page", pageNum, "is left empty for", errPage)
+			}
+		}
+
+		w.parse(b, el, pageNum, "", sec)
+
+		if pg.Pages > 0 && len(seen) == pg.Pages {
+			return nil
+		}
+
+		next.MustClick()
+	}
+
+	log.Println("stopped paging", el.Path(), "after", pg.Max, "clicks with", len(seen), "pages read")
+
+	return nil
+}
+
+// tabs clicks every tab of a block and reads items shown for it, from the panel of the tab if the block has panels.
+func (w *walker) tabs(b Block, el selector.Element, sec types.Section) error {
+	defer func() {
+		w.segment = segment{}
+	}()
+
+	tabs := el.Els(b.Tabs)
+	for idx, tab := range tabs {
+		if err := w.ctx.Err(); err != nil {
+			return err
+		}
+
+		tab.MustClick()
+		w.p.WaitRepaint()
+
+		if b.Demographic != nil {
+			w.segment = readSegment(tab, *b.Demographic)
+		}
+
+		within := el
+		if b.Panels != "" {
+			panels := el.Els(b.Panels)
+			if len(panels) != len(tabs) {
+				log.Println("skip tab", idx, "of", el.Path(), "for", len(panels), "panels of", len(tabs), "tabs")
+				continue
+			}
+
+			within = panels[idx]
+		}
+
+		w.parse(b, within, w.pageNum, "", sec)
+	}
+
+	return nil
+}
+
+// parse reads items and child blocks of a block, which tells whether it had any items.
+func (w *walker) parse(b Block, el selector.Element, pageNum int, shot string, sec types.Section) bool {
+	if b.Scroll {
+		w.p.ScrollTo(el.ElementTemplate)
+		w.p.WaitRepaint()
+	}
+
+	if b.Screenshot != nil {
+		// the box of the block scrolled into view is its own, whatever is above it in the page
+		if false == b.Scroll {
+			w.p.ScrollTo(el.ElementTemplate)
+			w.p.WaitRepaint()
+		}
+
+		shot = w.dd.TabScreenShot(pageNum)
+		w.p.ScreenShot(el.ElementTemplate, shot, b.Screenshot.Offset)
+	}
+
+	hasItems := w.items(b, el, pageNum, shot, sec)
+
+	for _, child := range b.Blocks {
+		childEl, err := el.First(child.Select...)
+		if err != nil {
+			continue
+		}
+
+		if true == w.parse(child, childEl, pageNum, shot, child.Section.of(childEl, sec)) {
+			hasItems = true
+		}
+	}
+
+	return hasItems
+}
+
+func (w *walker) items(b Block, el selector.Element, pageNum int, shot string, sec types.Section) bool {
+	groups := []selector.Element{el}
+	if b.Groups != "" {
+		groups = el.Els(b.Groups)
+	}
+
+	hasItems := false
+
+	for gdx, g := range groups {
+		spec, items := pickItems(g, b.Items)
+		if len(items) == 0 {
+			continue
+		}
+		hasItems = true
+
+		seg := w.segment
+		if b.Demographic != nil && b.Tabs == "" {
+			seg = readSegment(g, *b.Demographic)
+		}
+
+		order := b.OrderBase
+		switch b.Order {
+		case orderGroup:
+			order += gdx
+		case orderContinue:
+			order = nextOrder(w.news)
+		}
+
+		idx := 0
+		for _, item := range items {
+			if true == skip(item, spec.Skip) {
+				continue
+			}
+
+			n := types.News{
+				NewsPage:       pageNum,
+				Section:        sec,
+				Demographic:    seg.demographic,
+				Tab:            w.tab,
+				Order:          order,
+				SubOrder:       idx,
+				FullHTML:       w.dd.FullHTML(),
+				FullScreenShot: w.dd.FullScreenShot(),
+				TabScreenShot:  shot,
+			}
+
+			if b.Order == "" || b.Order == orderItem {
+				n.Order = order + idx
+				n.SubOrder = 0
+			}
+
+			w.item(item, spec, n, seg.err)
+
+			if spec.Related != nil {
+				for jdx, related := range item.Els(spec.Related.Select) {
+					n.SubOrder = jdx + 1
+					w.item(related, *spec.Related, n, seg.err)
+				}
+			}
+
+			idx++
+		}
+	}
+
+	return hasItems
+}
+
+// item reads an item into n, segmentErr is the error its segment failed to be read for.
+func (w *walker) item(item selector.Element, spec Items, n types.News, segmentErr error) {
+	f := selector.NewFields("")
+	f.Add("demographic", segmentErr)
+
+	fields := spec.Fields
+	for _, v := range spec.Variants {
+		if true == v.When.match(item) {
+			fields = v.Fields
+			break
+		}
+	}
+
+	for _, name := range sortedNames(fields) {
+		value := readField(item, name, fields[name], f)
+
+		switch name {
+		case "url":
+			n.URL = value
+		case "title":
+			n.Title = value
+		case "publisher":
+			n.Publisher = value
+		case "image":
+			n.Image = value
+		case "series_title":
+			n.SeriesTitle = value
+		}
+	}
+
+	w.news = selector.Append(w.news, n, f)
+}
+
+// of is the section of news of the block element el, parent for a block without a section.
+func (s *Section) of(el selector.Element, parent types.Section) types.Section {
+	if s == nil {
+		return parent
+	}
+
+	sec := types.Section{ID: s.ID, Label: s.Label}
+	if s.LabelAttr != "" {
+		if label, err := el.Attr(s.LabelAttr); err == nil && strings.TrimSpace(label) != "" {
+			sec.Label = strings.TrimSpace(label)
+		}
+	}
+
+	return sec
+}
+
+func (c Condition) match(e selector.Element) bool {
+	for _, sel := range c.Unless {
+		if true == e.Has(sel) {
+			return false
+		}
+	}
+
+	if len(c.Class) > 0 {
+		classAttr, _ := e.Attr("class")
+		for _, class := range c.Class {
+			if strings.Contains(classAttr, class) {
+				return true
+			}
+		}
+	}
+
+	for _, sel := range c.Has {
+		if true == e.Has(sel) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func skip(e selector.Element, conditions []Condition) bool {
+	for _, c := range conditions {
+		if true == c.match(e) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// pickItems takes the first of items fallbacks which has items under e.
+func pickItems(e selector.Element, fallbacks []Items) (Items, []selector.Element) {
+	for _, spec := range fallbacks {
+		if items := e.Els(spec.Select); len(items) > 0 {
+			return spec, items
+		}
+	}
+
+	return Items{}, nil
+}
+
+// found is the first of selectors has tells found, empty if none is.
+func found(has func(string) bool, selectors []string) string {
+	for _, sel := range selectors {
+		if true == has(sel) {
+			return sel
+		}
+	}
+
+	return ""
+}
+
+func branch(sel string) string {
+	if sel == "" {
+		return drift.BranchNone
+	}
+
+	return sel
+}
+
+// all is every element of the first of selectors found under s.
+func all(s scope, selectors []string) []selector.Element {
+	for _, sel := range selectors {
+		if els := s.Els(sel); len(els) > 0 {
+			return els
+		}
+	}
+
+	return nil
+}
+
+// readField reads a field into f, leaving it empty or default for an error. Missing optional fields are not errors.
+func readField(s scope, name string, fd Field, f *selector.Fields) string {
+	value, err := fieldValue(s, fd)
+	if err != nil && false == (fd.Optional && errors.Is(err, selector.ErrNotFound)) {
+		f.Add(name, err)
+	}
+
+	if value == "" {
+		return fd.Default
+	}
+
+	return value
+}
+
+// nextOrder continues order of the blocks parsed before, which starts from 0 for the first block.
+func nextOrder(newsList []types.News) int {
+	if len(newsList) == 0 {
+		return 0
+	}
+
+	return newsList[len(newsList)-1].Order + 1
+}
+
+func sortedNames(fields map[string]Field) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,131 @@
+package pack
+
+import (
+	"context"
+	"fmt"
+
+	rt "github.com/darimuri/go-lib/rodtemplate"
+
+	"github.com/darimuri/coll-news/pkg/drift"
+	"github.com/darimuri/coll-news/pkg/selector"
+	"github.com/darimuri/coll-news/pkg/types"
+	"github.com/darimuri/coll-news/pkg/util"
+)
+
+var _ types.CommentCollector = (*Collector)(nil)
+
+// GetComments reads top comments of the end of n from its comment panel, for packs which have comments.
+func (c *Collector) GetComments(ctx context.Context, p *rt.PageTemplate, n *types.News, option types.CommentOption) error {
+	cm := c.pack.End.Comments
+	if cm == nil || n.End == nil {
+		return nil
+	}
+
+	page := selector.NewPage(p, c.pack.Name, n.URL)
+	rec := drift.FromContext(ctx)
+
+	for _, sel := range cm.Open {
+		target, err := page.El(sel)
+		if err != nil {
+			record(rec, page, drift.PointEndComments, "closed:"+sel)
+			return nil
+		}
+
+		p.ScrollTo(target.ElementTemplate)
+		target.MustClick()
+		p.WaitRepaint()
+	}
+
+	if option.Sort != "" {
+		clicks, ok := cm.Sort[option.Sort]
+		if false == ok {
+			return fmt.Errorf("comment sort %s is not supported by selector pack %s", option.Sort, c.pack.Name)
+		}
+
+		for _, sel := range clicks {
+			target, err := page.El(sel)
+			if err != nil {
+				return err
+			}
+
+			target.MustClick()
+			p.WaitRepaint()
+		}
+	}
+
+	items := page.Els(cm.Select)
+	for ctx.Err() == nil && cm.More != "" && len(items) < option.Limit {
+		more, err := page.El(cm.More)
+		if err != nil || false == more.MustVisible() {
+			break
+		}
+
+		more.MustClick()
+		p.WaitRepaint()
+
+		shown := len(items)
+		if items = page.Els(cm.Select); len(items) == shown {
+			break
+		}
+	}
+
+	if len(items) == 0 {
+		record(rec, page, drift.PointEndComments, drift.BranchNone)
+	} else {
+		record(rec, page, drift.PointEndComments, cm.Select)
+	}
+
+	if len(items) > option.Limit {
+		items = items[:option.Limit]
+	}
+
+	f := selector.NewFields("end.comments.")
+	defer func() {
+		n.FieldErrors = append(n.FieldErrors, f.Errors()...)
+	}()
+
+	n.End.Comments = make([]types.Comment, 0, len(items))
+	for _, item := range items {
+		n.End.Comments = append(n.End.Comments, comment(item, cm.Fields, f))
+	}
+
+	return nil
+}
+
+func comment(item selector.Element, fields map[string]Field, f *selector.Fields) types.Comment {
+	cm := types.Comment{}
+
+	for _, name := range sortedNames(fields) {
+		value := readField(item, name, fields[name], f)
+
+		switch name {
+		case "author":
+			cm.AuthorHash = util.HashAuthor(value)
+		case "text":
+			cm.Text = value
+		case "posted_at":
+			cm.PostedAt = value
+		case "likes", "dislikes", "replies":
+			if value == "" {
+				continue
+			}
+
+			count, err := util.ParseCount(value)
+			if err != nil {
+				f.Add(name, err)
+				continue
+			}
+
+			switch name {
+			case "likes":
+				cm.Likes = count
+			case "dislikes":
+				cm.Dislikes = count
+			default:
+				cm.Replies = count
+			}
+		}
+	}
+
+	return cm
+}
@@ -0,0 +1,208 @@
+package pack
+
+const daumMobile = `
+name: daum/mobile
+schema: 1
+version: 4
+
+top:
+  present:
+  - div[id=channel_news1_top]
+  - div._box_feed_news1
+  blocks:
+  - name: feed
+    section: {id: feed, label: 피드, label_attr: data-tiara-layer}
+    select:
+    - div._box_feed_news1
+    each: true
+    scroll: true
+    items:
+    - select: ul.list_txt li
+      fields:
+        url: {select: [a], attr: href}
+        title: {select: [a]}
+    - select: ul.list_horizon li
+      fields:
+        url: {select: [a], attr: href}
+        title: {select: [a strong.tit_item, a span.tit_news]}
+        image: {select: [a div.wrap_thumb img], attr: src, optional: true}
+    - select: ul.list_thumb li
+      fields:
+        url: {select: [a], attr: href}
+        title: {select: ["div[class=cont_item] > strong[class=tit_item]"]}
+        image: {select: [img], attr: src, optional: true}
+    - select: ul.list_theme li
+      fields:
+        url: {select: [a], attr: href}
+        title: {select: ["div[class=cont_item] strong[class=tit_item]"], remove: [em]}
+        series_title: {select: ["div[class=cont_item] strong[class=tit_item] em"], optional: true}
+        publisher: {select: ["div[class=cont_item] span"]}
+
+home:
+  present:
+  - main[id=kakaoContent]
+  required:
+  - main[id=kakaoContent] div.section_main
+  - main[id=kakaoContent] div.section_sub
+  prepare:
+  - within: main[id=kakaoContent] div.section_main div[data-tiara-layer=MAIN_NEWS]
+    select: a.link_more
+  blocks:
+  - name: issue
+    section: {id: issue, label: 홈 이슈}
+    select:
+    - main[id=kakaoContent] div.section_main div.box_homeissue ul.list_homeissue
+    items:
+    - select: li
+      fields:
+        url: {select: [div.cont_thumb a], attr: href}
+        title: {select: [div.cont_thumb span.inner_link strong.tit_thumb]}
+        publisher: {select: [div.cont_thumb span.inner_link span.txt_cp]}
+        image: {select: [div.wrap_thumb img], attr: src, optional: true}
+      related:
+        select: div.cont_sub
+        fields:
+          url: {select: [a], attr: href}
+          title: {select: [span.inner_link span.tit_sub]}
+          publisher: {select: [span.inner_link span.txt_cp]}
+  - name: main_news
+    section: {id: main_news, label: MAIN_NEWS, label_attr: data-tiara-layer}
+    select:
+    - main[id=kakaoContent] div.section_main div[data-tiara-layer=MAIN_NEWS]
+    scroll: true
+    order: continue
+    items:
+    - &news_items
+      select: ul > li
+      skip:
+      - class: [item_bnr]
+      - has: [a.link_correction]
+        unless: [div.cont_thumb, div.item_cmtrank]
+      fields:
+        url: {select: [a], attr: href}
+        title:
+          select:
+          - div.cont_thumb > strong.tit_thumb span.txt_g
+          - div.cont_thumb > strong.tit_news span.txt_g
+          - div.item_cmtrank > strong.tit_cmtrank span.txt_g
+          - div.cont_thumb > strong.tit_thumb
+          - div.cont_thumb > strong.tit_news
+          - div.item_cmtrank > strong.tit_cmtrank
+        publisher:
+          select:
+          - div.cont_thumb > strong.tit_thumb span.txt_cp
+          - div.cont_thumb > strong.tit_news span.txt_cp
+          - div.item_cmtrank > strong.tit_cmtrank span.txt_cp
+          optional: true
+        image: {select: [div.wrap_thumb img], attr: src, optional: true}
+  - name: popular
+    section: {id: popular, label: POPULAR, label_attr: data-tiara-layer}
+    select:
+    - main[id=kakaoContent] div.section_sub div[data-tiara-layer=POPULAR]
+    scroll: true
+    order: continue
+    items:
+    - *news_items
+  - name: dri
+    section: {id: dri, label: DRI, label_attr: data-tiara-layer}
+    select:
+    - main[id=kakaoContent] div.section_sub div[data-tiara-layer=DRI]
+    scroll: true
+    order: continue
+    items:
+    - *news_items
+  - name: comment_rank
+    section: {id: comment_rank, label: 댓글 많은 뉴스}
+    select:
+    - main[id=kakaoContent] div.section_sub div.box_cmtrank
+    scroll: true
+    order: continue
+    items:
+    - *news_items
+  # the slide of a clicked tab is not the one shown yet, so items are read from the panel of the tab
+  - name: age_news
+    section: {id: age_news, label: 연령별 뉴스}
+    select:
+    - main[id=kakaoContent] div.section_sub
+    page: same
+    tabs: div.box_agenews > ul > li
+    panels: div.tab_slide > div.slide > div.panel > ul.list_news > div.slide > div.panel
+    demographic: {}
+    order: continue
+    items:
+    - select: li
+      fields:
+        url: {select: [a], attr: href}
+        title: {}
+
+end:
+  content:
+  - div[id=daumContent]
+  - main[id=kakaoContent]
+  - main[id=daumContent]
+  unsupported:
+  - select: main[class=doc-main]
+    error: main[class=doc-main] is not supported content block
+  missing:
+  - article[id=mArticle]
+  kinds:
+  - name: article
+    when: article[id=mArticle] div[data-cloud-area=article]
+    require:
+    - select: div[class=head_view] em[class=info_cp] > a[class=link_cp] > picture
+      error: content provider block is missing
+    html: true
+    fields:
+      title: {select: ["div[class=head_view] h3[class=tit_view]"], required: true}
+      text:
+        select: ["article[id=mArticle] div[data-cloud-area=article] div[data-cloud=article_body] div[class=article_view]"]
+        remove: [figure]
+        required: true
+      category: {select: ["h2[class=screen_out]"]}
+      provider:
+        select: ["div[class=head_view] em[class=info_cp] > a[class=link_cp] > picture img"]
+        attr: alt
+        from_html: true
+      posted_at: {select: ["div[class=head_view] div[class=info_view] span[class=txt_info]"], index: 0, trim: [입력]}
+      modified_at: {select: ["div[class=head_view] div[class=info_view] span[class=txt_info]"], index: 1, trim: [수정], optional: true}
+      author: {select: ["div[class=head_view] div[class=info_view] span[class=txt_author]"], optional: true, default: NotFound}
+      num_comment: {select: ["div[class=head_view] button[id=alexCounter] span.alex-count-area"], optional: true}
+      images:
+        select: ["article[id=mArticle] div[data-cloud-area=article] img[class=thumb_g_article]"]
+        attr: src
+        all: true
+    emotions:
+      select: article[id=mArticle] div[data-cloud-area=article] div.emotion_wrap > div.emotion_list > div.alex-action > div > div.list-wrapper div.selectionbox
+      name: {attr: data-tiara-action-name, trim: [액션_]}
+      count: {select: [span.count]}
+  - name: video
+    when: article[id=mArticle] div[id=videoWrap]
+    fields:
+      title:
+        select: ["article[id=mArticle] div[id=videoWrap] div[class=inner_view] div[class=box_vod] div[class=cont_vod] h4[class=tit_vod] span[class=inner_tit] span[class=inner_tit2]"]
+        required: true
+      program: {select: ["article[id=mArticle] div[id=videoWrap] div[class=inner_view] h3[class=tit_program] span[class=wrap_thumb] img"], attr: alt}
+      provider: {select: ["article[id=mArticle] div[id=videoWrap] div[class=inner_view] h3[class=tit_program] a[class=btn_allview] span"]}
+      num_played: {select: ["div[class=info_vod] span"], index: 1}
+      posted_at: {select: ["div[class=info_vod] span"], index: 3, trim: [등록]}
+  skip:
+  - article[id=mArticle] div[class=photo_view]
+  - div[class=view_vod]
+  - div[class=cont_vod]
+  - div[data-tiara-layer=c_viewcontents]
+  comments:
+    open:
+    - button[id=alexCounter]
+    sort:
+      recommended: [div.alex-area button.btn_sort, "div.alex-area a[data-value=RECOMMEND]"]
+      latest: [div.alex-area button.btn_sort, "div.alex-area a[data-value=LATEST]"]
+    more: div.alex-area div.alex_more button
+    select: div.alex-area ul.list_comment > li
+    fields:
+      author: {select: [a.link_nick, strong.tit_g, span.txt_name], optional: true}
+      text: {select: [p.desc_txt]}
+      likes: {select: [button.btn_recomm span.num_txt], optional: true}
+      dislikes: {select: [button.btn_oppose span.num_txt], optional: true}
+      replies: {select: [button.reply_count span.num_txt, button.btn_reply span.num_txt], optional: true}
+      posted_at: {select: [span.txt_date], optional: true}
+`
@@ -0,0 +1,195 @@
+package pack
+
+const daumPC = `
+name: daum/pc
+schema: 1
+version: 7
+
+top:
+  present:
+  - div[id=mediaTab]
+  blocks:
+  - name: media
+    section: {id: media, label: 언론사별 뉴스}
+    select:
+    - div[id=mediaTab]
+    screenshot: {}
+    pager:
+      current: strong[class=num_index]
+      next: div[class=page_tabcont]
+      max: 30
+      label: {select: ["div[class=page_tabcont] strong[class=screen_out]"], optional: true}
+    blocks:
+    - name: thumb
+      select:
+      - div[class=group_news] ul[class=list_thumb]
+      items:
+      - select: li
+        fields:
+          url: {select: [a], attr: href}
+          title: {select: ["div[class=cont_item] > strong[class=tit_item]"]}
+          image: {select: [img], attr: src, optional: true}
+    - name: text
+      select:
+      - div[class=group_news] ul[class=list_txt]
+      items:
+      - select: li
+        fields:
+          url: {select: [a], attr: href}
+          title: {select: [a]}
+
+home:
+  required:
+  - "#mArticle"
+  blocks:
+  - name: headline
+    section: {id: headline, label: 헤드라인}
+    select:
+    - "#mArticle div.box_news_headline2"
+    - "#mArticle div.box_news_major"
+    - "#cMain #mArticle div[class=box_headline]"
+    screenshot: {}
+    groups: ul
+    order: group
+    items:
+    - select: li
+      fields:
+        url: {select: [a.link_txt, a], attr: href}
+        title: {select: [strong.tit_txt, a.link_txt, a]}
+        publisher: {select: [span.txt_info, "span[class=info_news]"], optional: true}
+        image: {select: [img], attr: src, optional: true}
+      variants:
+      - when: {class: [item_main]}
+        fields:
+          url: {select: [a], attr: href}
+          title: {select: ["strong[class=tit_g]"]}
+          image: {select: [img], attr: src, optional: true}
+  - name: issue
+    section: {id: issue, label: 이슈}
+    select:
+    - "#mArticle div.box_news_issue"
+    - "#cSub ul[class=list_issue]"
+    screenshot: {}
+    items:
+    - select: ul.list_newsissue > li
+      fields:
+        url: {select: [a.link_txt, strong > a], attr: href}
+        title: {select: [strong.tit_txt, a.link_txt, strong > a]}
+        publisher: {select: [span.txt_info], optional: true}
+        image: {select: [img], attr: src, optional: true}
+    - select: li
+      fields:
+        url: {select: ["div[class=cont_thumb] strong > a"], attr: href}
+        title: {select: ["div[class=cont_thumb] strong > a"]}
+        publisher: {select: ["div[class=cont_thumb] span[class=info_thumb]"]}
+        image: {select: ["div[class=item_issue] img"], attr: src, optional: true}
+      related:
+        select: div[class=relate_thumb] div[class=thumb_relate]
+        fields:
+          url: {select: [a], attr: href}
+          title: {select: [a]}
+          publisher: {select: ["span[class=info_news]"]}
+  - name: peruse
+    section: {id: peruse, label: 많이 본 뉴스}
+    select:
+    - "#mArticle div.box_news_ranking"
+    - "#cMain #mArticle div[class=box_peruse] > div[class='pop_news pop_cmt']"
+    screenshot: &block_screenshot {}
+    order: fixed
+    order_base: 0
+    items:
+    - &pop_items
+      select: ol li
+      fields:
+        url: {select: [a.link_txt, a], attr: href}
+        title: {select: [strong.tit_txt, a.link_txt, a]}
+        publisher: {select: [span.txt_info, "span[class=info_news]"], optional: true}
+  - name: popular_comments
+    section: {id: popular_comments, label: 댓글 많은 뉴스}
+    select:
+    - "#mArticle div.box_news_cmtrank"
+    - "#cMain #mArticle div[class='box_g box_popnews'] > div[class='pop_news pop_cmt']"
+    screenshot: *block_screenshot
+    order: fixed
+    order_base: 1
+    items:
+    - *pop_items
+  - name: popular_ages
+    section: {id: popular_ages, label: 연령별 인기 뉴스}
+    select:
+    - "#cMain #mArticle div[class='pop_news pop_age']"
+    screenshot: {}
+    groups: div
+    demographic: {select: [strong, h4, h3]}
+    order: group
+    order_base: 2
+    items:
+    - <<: *pop_items
+      select: ul li
+
+end:
+  content:
+  - div[id=daumContent]
+  - main[id=daumContent]
+  - div[id=kakaoContent]
+  - main[id=kakaoContent]
+  missing:
+  - div[id=cMain]
+  kinds:
+  - name: article
+    when: div[id=cMain] div[id=mArticle] div[data-cloud-area=article]
+    html: true
+    fields:
+      title: {select: ["div[class=head_view] h3[class=tit_view]"], required: true}
+      text: {select: ["div[id=cMain] div[id=mArticle] div[data-cloud-area=article]"], required: true}
+      category: {select: ["h2[id=kakaoBody]"], root: page}
+      provider: {select: ["div[class=head_view] em[class=info_cp] > a[class=link_cp] img"], attr: alt, optional: true}
+      posted_at:
+        select: ["div[class=head_view] span[class=info_view] span[class=txt_info]"]
+        contains: "입력 "
+        trim: ["입력 "]
+      modified_at:
+        select: ["div[class=head_view] span[class=info_view] span[class=txt_info]"]
+        contains: "수정 "
+        trim: ["수정 "]
+        optional: true
+      author:
+        select: ["div[class=head_view] span[class=info_view] span[class=txt_info]"]
+        excludes: ["입력 ", "수정 "]
+        optional: true
+      num_comment:
+        select: ["div[class=head_view] span[class=info_view] button[id=alexCounter] span[class=alex-count-area]"]
+        optional: true
+      images:
+        select: ["div[id=cMain] div[id=mArticle] div[data-cloud-area=article] img[class=thumb_g_article]"]
+        attr: src
+        all: true
+  - name: video
+    when: div[id=cMain] div[id=mArticle] div[id=videoWrap]
+    fields:
+      title:
+        select: ["div[id=videoWrap] div[class=inner_view] div[class=box_vod] div[class=cont_vod] h4[class=tit_vod] span[class=inner_tit] span[class=inner_tit2]"]
+        required: true
+      program: {select: ["div[id=videoWrap] div[class=inner_view] h3[class=tit_program] span[class=wrap_thumb] img"], attr: alt}
+      provider: {select: ["div[id=videoWrap] div[class=inner_view] h3[class=tit_program] a[class=btn_allview] span"]}
+      num_played: {select: ["div[class=info_vod] span"], index: 1}
+      posted_at: {select: ["div[class=info_vod] span"], index: 3, trim: ["등록"]}
+  skip:
+  - div[id=cMain] div[id=mArticle] div[class=photo_view]
+  - div[class=view_vod]
+  comments:
+    open:
+    - button[id=alexCounter]
+    sort:
+      recommended: [div.alex-area button.btn_sort, "div.alex-area a[data-value=RECOMMEND]"]
+      latest: [div.alex-area button.btn_sort, "div.alex-area a[data-value=LATEST]"]
+    more: div.alex-area div.alex_more button
+    select: div.alex-area ul.list_comment > li
+    fields:
+      author: {select: [a.link_nick, strong.tit_g, span.txt_name], optional: true}
+      text: {select: [p.desc_txt]}
+      likes: {select: [button.btn_recomm span.num_txt], optional: true}
+      dislikes: {select: [button.btn_oppose span.num_txt], optional: true}
+      replies: {select: [button.reply_count span.num_txt, button.btn_reply span.num_txt], optional: true}
+      posted_at: {select: [span.txt_date], optional: true}
+`
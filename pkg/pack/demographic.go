@@ -0,0 +1,60 @@
+package pack
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/darimuri/coll-news/pkg/types"
+)
+
+const (
+	genderFemale = "female"
+	genderMale   = "male"
+)
+
+var (
+	femaleWords = []string{"여성", "여자"}
+	maleWords   = []string{"남성", "남자"}
+
+	ageRegexp = regexp.MustCompile(`(\d+)\s*대(\s*이상)?`)
+)
+
+// demographic reads gender and age of a segment label such as 30대 여성 or 남자 50대 이상.
+func demographic(label string) *types.Demographic {
+	d := &types.Demographic{Label: strings.Join(strings.Fields(label), " ")}
+
+	switch {
+	case containsAny(d.Label, femaleWords):
+		d.Gender = genderFemale
+	case containsAny(d.Label, maleWords):
+		d.Gender = genderMale
+	}
+
+	if m := ageRegexp.FindStringSubmatch(d.Label); m != nil {
+		d.Age = m[1] + "s"
+		if m[2] != "" {
+			d.Age += "+"
+		}
+	}
+
+	return d
+}
+
+// segment is the reader segment of a group or a tab, with the error it failed to be read for.
+type segment struct {
+	demographic *types.Demographic
+	err         error
+}
+
+func readSegment(e scope, fd Field) segment {
+	label, err := fieldValue(e, fd)
+	if err != nil {
+		return segment{err: err}
+	}
+
+	if strings.TrimSpace(label) == "" {
+		return segment{}
+	}
+
+	return segment{demographic: demographic(label)}
+}
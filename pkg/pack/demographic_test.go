@@ -0,0 +1,24 @@
+package pack
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/darimuri/coll-news/pkg/types"
+)
+
+var _ = Describe("demographic", func() {
+	It("reads gender and age of segment labels", func() {
+		labels := map[string]types.Demographic{
+			"30대 여성":    {Gender: genderFemale, Age: "30s", Label: "30대 여성"},
+			"남자 50대 이상": {Gender: genderMale, Age: "50s+", Label: "남자 50대 이상"},
+			" 20대\n":    {Age: "20s", Label: "20대"},
+			"여성":        {Gender: genderFemale, Label: "여성"},
+			"많이 본 뉴스":   {Label: "많이 본 뉴스"},
+		}
+
+		for label, expected := range labels {
+			Expect(*demographic(label)).Should(Equal(expected), label)
+		}
+	})
+})
@@ -0,0 +1,210 @@
+package pack
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"strings"
+
+	rt "github.com/darimuri/go-lib/rodtemplate"
+
+	"github.com/darimuri/coll-news/pkg/adaptor"
+	"github.com/darimuri/coll-news/pkg/drift"
+	"github.com/darimuri/coll-news/pkg/selector"
+	"github.com/darimuri/coll-news/pkg/types"
+	"github.com/darimuri/coll-news/pkg/util"
+)
+
+func (c *Collector) GetNewsEnd(ctx context.Context, p *rt.PageTemplate, n *types.News) error {
+	e := c.pack.End
+	page := selector.NewPage(p, c.pack.Name, n.URL)
+	rec := drift.FromContext(ctx)
+
+	sel := found(page.Has, e.Content)
+	record(rec, page, drift.PointEndContent, branch(sel))
+
+	if sel == "" {
+		for _, rule := range e.Unsupported {
+			if true == page.Has(rule.Select) {
+				record(rec, page, drift.PointEndKind, "unsupported:"+rule.Select)
+				return adaptor.NewTypedError(rule.Error)
+			}
+		}
+
+		return page.Errorf(strings.Join(e.Content, ", "), "%w", selector.ErrNotFound)
+	}
+
+	content, err := page.El(sel)
+	if err != nil {
+		return err
+	}
+
+	for _, sel := range e.Missing {
+		if false == content.Has(sel) {
+			record(rec, page, drift.PointEndKind, "missing:"+sel)
+			log.Printf("block %s is missing in %s\n", sel, n.URL)
+			return nil
+		}
+	}
+
+	for _, k := range e.Kinds {
+		if true == content.Has(k.When) {
+			record(rec, page, drift.PointEndKind, k.Name)
+			return end(page, content, k, n)
+		}
+	}
+
+	for _, sel := range e.Skip {
+		if true == content.Has(sel) {
+			record(rec, page, drift.PointEndKind, "skip:"+sel)
+			log.Println("skip to collect news end of", sel, "for", n.URL)
+			return nil
+		}
+	}
+
+	record(rec, page, drift.PointEndKind, drift.BranchNone)
+
+	return page.Errorf(content.Path(), "failed to collect new end for %s", n.URL)
+}
+
+// record counts branch matched at point with a sample of the page for the first one.
+func record(rec *drift.Recorder, page *selector.Page, point, branch string) {
+	rec.Match(point, branch)
+	rec.Sample(point, branch, func() (string, error) {
+		html, err := page.El("html")
+		if err != nil {
+			return "", err
+		}
+
+		return html.HTML()
+	})
+}
+
+func end(page *selector.Page, content selector.Element, k Kind, n *types.News) error {
+	for _, rule := range k.Require {
+		if false == content.Has(rule.Select) {
+			return adaptor.NewTypedError(rule.Error)
+		}
+	}
+
+	f := selector.NewFields("end.")
+	defer func() {
+		n.FieldErrors = append(n.FieldErrors, f.Errors()...)
+	}()
+
+	e := &types.End{}
+
+	for _, name := range sortedNames(k.Fields) {
+		fd := k.Fields[name]
+
+		var s scope = content
+		if fd.Root == rootPage {
+			s = page
+		}
+
+		if fd.All {
+			values, errs := fieldValues(s, fd)
+			for _, err := range errs {
+				f.Add(name, err)
+			}
+			setEndValues(e, name, values)
+			continue
+		}
+
+		if fd.Required {
+			value, err := fieldValue(s, fd)
+			if err != nil {
+				return err
+			}
+			setEnd(e, name, value, f)
+			continue
+		}
+
+		setEnd(e, name, readField(s, name, fd, f), f)
+	}
+
+	if k.HTML {
+		if html, errHTML := page.El("html"); errHTML == nil {
+			e.HTML, errHTML = html.HTML()
+			f.Add("html", errHTML)
+		}
+	}
+
+	if k.Emotions != nil {
+		e.Emotions = emotions(content, k.Emotions, f)
+	}
+
+	n.End = e
+
+	return nil
+}
+
+func setEnd(e *types.End, name, value string, f *selector.Fields) {
+	switch name {
+	case "title":
+		e.Title = value
+	case "text":
+		e.Text = value
+	case "category":
+		e.Category = value
+	case "provider":
+		e.Provider = value
+	case "author":
+		e.Author = value
+	case "program":
+		e.Program = value
+	case "posted_at":
+		e.PostedAt = value
+	case "modified_at":
+		e.ModifiedAt = value
+	case "num_comment", "num_played":
+		if value == "" {
+			return
+		}
+
+		count, err := util.ParseCount(value)
+		if err != nil {
+			f.Add(name, err)
+			return
+		}
+
+		if name == "num_comment" {
+			e.NumComment = count
+		} else {
+			e.NumPlayed = count
+		}
+	}
+}
+
+func setEndValues(e *types.End, name string, values []string) {
+	switch name {
+	case "images":
+		e.Images = values
+	}
+}
+
+func emotions(content selector.Element, em *Emotions, f *selector.Fields) []types.Emotion {
+	boxes := content.Els(em.Select)
+	if len(boxes) == 0 {
+		return nil
+	}
+
+	list := make([]types.Emotion, 0)
+
+	for _, box := range boxes {
+		emotionName := readField(box, "emotions", em.Name, f)
+		emotionCount := readField(box, "emotions", em.Count, f)
+
+		if emotionCount == "" {
+			log.Println("skip emotion collection of", emotionName, "for empty emotionCount string in", box.Path())
+		}
+
+		if count, errCount := strconv.ParseInt(emotionCount, 10, 64); errCount != nil {
+			list = append(list, types.Emotion{Name: emotionName, CountString: emotionCount})
+		} else {
+			list = append(list, types.Emotion{Name: emotionName, Count: count})
+		}
+	}
+
+	return list
+}
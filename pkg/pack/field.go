@@ -0,0 +1,141 @@
+package pack
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/darimuri/coll-news/pkg/selector"
+	"github.com/darimuri/coll-news/pkg/util"
+)
+
+// fieldValue reads fd of the element picked under s, or of s itself when fd has no selector.
+func fieldValue(s scope, fd Field) (string, error) {
+	if len(fd.Select) == 0 {
+		e, ok := s.(selector.Element)
+		if false == ok {
+			return "", nil
+		}
+
+		return read(e, fd)
+	}
+
+	e, err := pick(s, fd)
+	if err != nil {
+		return "", err
+	}
+
+	return read(e, fd)
+}
+
+// fieldValues reads fd of every element matching it under s.
+func fieldValues(s scope, fd Field) ([]string, []error) {
+	values := make([]string, 0)
+	errs := make([]error, 0)
+
+	for _, e := range all(s, fd.Select) {
+		value, err := read(e, fd)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values, errs
+}
+
+// pick selects the first found of fd, or the one of them chosen by index, contains or excludes.
+func pick(s scope, fd Field) (selector.Element, error) {
+	if fd.Index == nil && fd.Contains == "" && len(fd.Excludes) == 0 {
+		return s.First(fd.Select...)
+	}
+
+	candidates := all(s, fd.Select)
+	if len(candidates) == 0 {
+		return s.First(fd.Select...)
+	}
+
+	if fd.Index != nil {
+		if *fd.Index >= len(candidates) {
+			return selector.Element{}, candidates[0].Errorf("%w: only %d of %d", selector.ErrNotFound, len(candidates), *fd.Index+1)
+		}
+
+		return candidates[*fd.Index], nil
+	}
+
+	picked := -1
+	for idx, e := range candidates {
+		text, err := e.Text()
+		if err != nil {
+			continue
+		}
+
+		if fd.Contains != "" && false == strings.Contains(text, fd.Contains) {
+			continue
+		}
+
+		if true == containsAny(text, fd.Excludes) {
+			continue
+		}
+
+		picked = idx
+	}
+
+	if picked < 0 {
+		return selector.Element{}, candidates[0].Errorf("%w: none contains '%s' without %v", selector.ErrNotFound, fd.Contains, fd.Excludes)
+	}
+
+	return candidates[picked], nil
+}
+
+func read(e selector.Element, fd Field) (string, error) {
+	var value string
+	var err error
+
+	if fd.Attr != "" {
+		value, err = e.Attr(fd.Attr)
+		if value == "" && fd.FromHTML {
+			if html, errHTML := e.HTML(); errHTML == nil {
+				value, err = util.AttributeFromHTML(html, fd.Attr), nil
+			}
+		}
+	} else {
+		value, err = e.Text()
+		for _, sel := range fd.Remove {
+			if false == e.Has(sel) {
+				continue
+			}
+
+			if removed, errRemove := e.ElText(sel); errRemove == nil && removed != "" {
+				value = strings.TrimSpace(strings.Replace(value, removed, "", 1))
+			}
+		}
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	for _, word := range fd.Trim {
+		value = strings.TrimSpace(strings.ReplaceAll(value, word, ""))
+	}
+
+	return value, nil
+}
+
+func containsAny(s string, words []string) bool {
+	for _, word := range words {
+		if strings.Contains(s, word) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parsePage(s string) (int, error) {
+	return strconv.Atoi(strings.TrimSpace(s))
+}
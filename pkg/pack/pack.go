@@ -0,0 +1,484 @@
+package pack
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/darimuri/coll-news/pkg/types"
+)
+
+// Schema is the latest schema of selector packs. Packs of a newer schema are refused.
+const Schema = 1
+
+const (
+	orderItem     = "item"
+	orderGroup    = "group"
+	orderFixed    = "fixed"
+	orderContinue = "continue"
+
+	pageNext = "next"
+	pageSame = "same"
+
+	rootContent = "content"
+	rootPage    = "page"
+)
+
+// Pack is the selectors of a collector, which a Collector interprets to collect news lists and ends.
+type Pack struct {
+	Name string `yaml:"name"`
+	// Schema is the format of the pack and Version is bumped whenever selectors of the pack change.
+	Schema  int  `yaml:"schema"`
+	Version int  `yaml:"version"`
+	Top     List `yaml:"top"`
+	Home    List `yaml:"home"`
+	End     End  `yaml:"end"`
+}
+
+// List is a news list page made of blocks.
+type List struct {
+	// Present are selectors all of which the page should have to have news, otherwise the list is empty.
+	Present []string `yaml:"present"`
+	// Required are selectors all of which the page should have, otherwise the list fails.
+	Required []string `yaml:"required"`
+	// Prepare are clicks made before the screenshot of the page.
+	Prepare []Click `yaml:"prepare"`
+	Blocks  []Block `yaml:"blocks"`
+}
+
+// Click scrolls to Within and clicks Select under it while it is visible, e.g. to unfold more news.
+type Click struct {
+	Within string `yaml:"within"`
+	Select string `yaml:"select"`
+}
+
+// Block is a part of a list page which has its own news page number.
+type Block struct {
+	Name string `yaml:"name"`
+	// Section names news of the block, child blocks without a section of their own take the one of their parent.
+	Section *Section `yaml:"section"`
+	// Select are fallbacks of the block, the first found is taken. A missing block is skipped.
+	Select []string `yaml:"select"`
+	// Each takes every element of the first found selector as a block of its own page.
+	Each bool `yaml:"each"`
+	// Page is next(default) to take the next page number, or same to stay in the page of the block before.
+	Page       string      `yaml:"page"`
+	Scroll     bool        `yaml:"scroll"`
+	Screenshot *Screenshot `yaml:"screenshot"`
+	// Pager walks pages of the block which are numbered in the block itself.
+	Pager *Pager `yaml:"pager"`
+	// Tabs are clicked one by one and items of the block are read for every tab.
+	Tabs string `yaml:"tabs"`
+	// Panels are panels of tabs in the order of tabs, items of a tab are read from its own panel.
+	Panels string `yaml:"panels"`
+	// Groups split items of the block, each group has an order of its own.
+	Groups string `yaml:"groups"`
+	// Demographic is read from every tab or group as the label of its reader segment, e.g. 30대 여성.
+	Demographic *Field `yaml:"demographic"`
+	// Order is how order and sub order of items are numbered, item(default), group, fixed or continue.
+	Order     string `yaml:"order"`
+	OrderBase int    `yaml:"order_base"`
+	// Items are fallbacks of the items, the first which has items is taken.
+	Items []Items `yaml:"items"`
+	// Blocks are parts of the block in the same page.
+	Blocks []Block `yaml:"blocks"`
+}
+
+// key names the block in drift records, by its name or its first selector.
+func (b Block) key() string {
+	if b.Name != "" {
+		return b.Name
+	}
+
+	return b.Select[0]
+}
+
+// Section is the section of news of a block. Label is read from LabelAttr of the block element when
+// the element has it, e.g. data-tiara-layer.
+type Section struct {
+	ID        string `yaml:"id"`
+	Label     string `yaml:"label"`
+	LabelAttr string `yaml:"label_attr"`
+}
+
+// Screenshot of a block is taken of its own box after the block is scrolled into view, shifted by Offset.
+type Screenshot struct {
+	Offset float64 `yaml:"offset"`
+}
+
+// Pager walks pages by clicking Next until it cycles back to the first page, Pages pages are read or
+// Max clicks are made. Label is read for every page as the tab of its news.
+type Pager struct {
+	Current string `yaml:"current"`
+	Next    string `yaml:"next"`
+	Max     int    `yaml:"max"`
+	Pages   int    `yaml:"pages"`
+	Label   *Field `yaml:"label"`
+}
+
+type Items struct {
+	Select string `yaml:"select"`
+	// Skip are banners and such which are not news, skipped items do not take a sub order.
+	Skip     []Condition      `yaml:"skip"`
+	Fields   map[string]Field `yaml:"fields"`
+	Variants []Variant        `yaml:"variants"`
+	// Related are news under an item which has the order of the item and sub orders from 1.
+	Related *Items `yaml:"related"`
+}
+
+// Variant replaces fields of items matching When.
+type Variant struct {
+	When   Condition        `yaml:"when"`
+	Fields map[string]Field `yaml:"fields"`
+}
+
+// Condition matches an element whose class has one of Class or which has one of Has, unless it has one of Unless.
+type Condition struct {
+	Class  []string `yaml:"class"`
+	Has    []string `yaml:"has"`
+	Unless []string `yaml:"unless"`
+}
+
+func (c Condition) empty() bool {
+	return len(c.Class) == 0 && len(c.Has) == 0
+}
+
+// Field is a value read from text or Attr of the first found of Select, or of the element itself without Select.
+type Field struct {
+	Select []string `yaml:"select"`
+	Attr   string   `yaml:"attr"`
+	// FromHTML reads Attr from html of the element when it is not rendered yet.
+	FromHTML bool `yaml:"from_html"`
+	// Optional fields are left empty without a field error when missing.
+	Optional bool `yaml:"optional"`
+	// Required fields of an end fail the end when missing.
+	Required bool   `yaml:"required"`
+	Default  string `yaml:"default"`
+	// Root of an end field is content(default) or page.
+	Root string `yaml:"root"`
+	// Index, Contains and Excludes pick one of the elements matching Select.
+	Index    *int     `yaml:"index"`
+	Contains string   `yaml:"contains"`
+	Excludes []string `yaml:"excludes"`
+	// All reads every element matching Select, for images.
+	All bool `yaml:"all"`
+	// Remove are children whose text is removed from the value.
+	Remove []string `yaml:"remove"`
+	// Trim are words removed from the value.
+	Trim []string `yaml:"trim"`
+}
+
+// End is a news end page of kinds such as article and video.
+type End struct {
+	Content []string `yaml:"content"`
+	// Unsupported fail with a typed error when the page has no content but has one of them.
+	Unsupported []Rule `yaml:"unsupported"`
+	// Missing under content means no end is collected.
+	Missing []string `yaml:"missing"`
+	Kinds   []Kind   `yaml:"kinds"`
+	// Skip under content are known ends which are not collected.
+	Skip []string `yaml:"skip"`
+	// Comments are read from the comment panel of ends, only when comments are asked.
+	Comments *Comments `yaml:"comments"`
+}
+
+// Comments opens the comment panel of an end by clicking Open in order, sorts it by clicking the selectors of
+// the sort asked in order, and clicks More while fewer comments than asked are shown. Fields are read of every
+// Select. An end without Open has no comments.
+type Comments struct {
+	Open   []string            `yaml:"open"`
+	Sort   map[string][]string `yaml:"sort"`
+	More   string              `yaml:"more"`
+	Select string              `yaml:"select"`
+	Fields map[string]Field    `yaml:"fields"`
+}
+
+// Rule makes a typed error of Error for Select.
+type Rule struct {
+	Select string `yaml:"select"`
+	Error  string `yaml:"error"`
+}
+
+// Kind is an end matching When under content.
+type Kind struct {
+	Name string `yaml:"name"`
+	When string `yaml:"when"`
+	// Require fail the end with a typed error when missing under content.
+	Require  []Rule           `yaml:"require"`
+	Fields   map[string]Field `yaml:"fields"`
+	Emotions *Emotions        `yaml:"emotions"`
+	HTML     bool             `yaml:"html"`
+}
+
+// Emotions are reactions to an end, each of Select with Name and Count.
+type Emotions struct {
+	Select string `yaml:"select"`
+	Name   Field  `yaml:"name"`
+	Count  Field  `yaml:"count"`
+}
+
+var (
+	itemFields    = map[string]bool{"url": true, "title": true, "publisher": true, "image": true, "series_title": true}
+	commentFields = map[string]bool{"author": true, "text": true, "likes": true, "dislikes": true, "replies": true, "posted_at": true}
+	endFields     = map[string]bool{
+		"title": true, "text": true, "category": true, "provider": true, "author": true, "program": true,
+		"posted_at": true, "modified_at": true, "num_comment": true, "num_played": true, "images": true,
+	}
+)
+
+// Parse reads a pack and checks it can be interpreted.
+func Parse(data []byte) (*Pack, error) {
+	p := &Pack{}
+	if err := yaml.UnmarshalStrict(data, p); err != nil {
+		return nil, err
+	}
+
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// FileName is the name of the pack in an override directory, e.g. daum-pc.yaml for daum/pc.
+func FileName(name string) string {
+	return strings.ReplaceAll(name, "/", "-") + ".yaml"
+}
+
+// Load reads the pack of name from overrideDir if it is there, otherwise the built-in pack.
+func Load(name, overrideDir string) (*Pack, error) {
+	if overrideDir != "" {
+		path := filepath.Join(overrideDir, FileName(name))
+		data, err := ioutil.ReadFile(path)
+		if err == nil {
+			p, errParse := Parse(data)
+			if errParse != nil {
+				return nil, fmt.Errorf("selector pack %s: %w", path, errParse)
+			}
+			if p.Name != name {
+				return nil, fmt.Errorf("selector pack %s is for %s, not %s", path, p.Name, name)
+			}
+
+			log.Println("selector pack", name, "version", p.Version, "is overridden by", path)
+			return p, nil
+		} else if false == os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+
+	data, ok := builtin[name]
+	if false == ok {
+		return nil, fmt.Errorf("selector pack %s is not built in", name)
+	}
+
+	p, err := Parse([]byte(data))
+	if err != nil {
+		return nil, fmt.Errorf("built-in selector pack %s: %w", name, err)
+	}
+
+	return p, nil
+}
+
+func (p *Pack) validate() error {
+	if p.Name == "" {
+		return fmt.Errorf("name is missing")
+	}
+
+	if p.Schema < 1 || p.Schema > Schema {
+		return fmt.Errorf("schema %d of %s is not supported, up to %d", p.Schema, p.Name, Schema)
+	}
+
+	for path, l := range map[string]List{"top": p.Top, "home": p.Home} {
+		for idx, b := range l.Blocks {
+			if b.Section == nil {
+				return fmt.Errorf("%s.blocks[%d]: section is missing", path, idx)
+			}
+			if err := b.validate(fmt.Sprintf("%s.blocks[%d]", path, idx)); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(p.Top.Prepare) > 0 {
+		return fmt.Errorf("top.prepare is not supported, top is screenshot as it is")
+	}
+
+	for idx, c := range p.Home.Prepare {
+		if c.Select == "" {
+			return fmt.Errorf("home.prepare[%d]: select is missing", idx)
+		}
+	}
+
+	return p.End.validate()
+}
+
+func (b Block) validate(path string) error {
+	if b.Name != "" {
+		path = path + "(" + b.Name + ")"
+	}
+
+	if len(b.Select) == 0 {
+		return fmt.Errorf("%s: select is missing", path)
+	}
+
+	if b.Section != nil && b.Section.ID == "" {
+		return fmt.Errorf("%s: section id is missing", path)
+	}
+
+	switch b.Order {
+	case "", orderItem, orderGroup, orderFixed, orderContinue:
+	default:
+		return fmt.Errorf("%s: order %s is unknown", path, b.Order)
+	}
+
+	switch b.Page {
+	case "", pageNext, pageSame:
+	default:
+		return fmt.Errorf("%s: page %s is unknown", path, b.Page)
+	}
+
+	if b.Order == orderGroup && b.Groups == "" {
+		return fmt.Errorf("%s: order group needs groups", path)
+	}
+
+	if b.Panels != "" && b.Tabs == "" {
+		return fmt.Errorf("%s: panels needs tabs", path)
+	}
+
+	if b.Demographic != nil && b.Tabs == "" && b.Groups == "" {
+		return fmt.Errorf("%s: demographic needs tabs or groups", path)
+	}
+
+	if b.Pager != nil && (b.Pager.Current == "" || b.Pager.Next == "" || b.Pager.Max < 1) {
+		return fmt.Errorf("%s: pager needs current, next and max", path)
+	}
+
+	if len(b.Items) == 0 && len(b.Blocks) == 0 {
+		return fmt.Errorf("%s: items or blocks are missing", path)
+	}
+
+	for idx, items := range b.Items {
+		if err := items.validate(fmt.Sprintf("%s.items[%d]", path, idx)); err != nil {
+			return err
+		}
+	}
+
+	for idx, child := range b.Blocks {
+		if child.Screenshot != nil || child.Pager != nil || child.Each {
+			return fmt.Errorf("%s.blocks[%d]: screenshot, pager and each are only for top blocks", path, idx)
+		}
+		if err := child.validate(fmt.Sprintf("%s.blocks[%d]", path, idx)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (i Items) validate(path string) error {
+	if i.Select == "" {
+		return fmt.Errorf("%s: select is missing", path)
+	}
+
+	if _, ok := i.Fields["url"]; false == ok {
+		return fmt.Errorf("%s: url field is missing", path)
+	}
+
+	if err := validateFields(path, i.Fields, itemFields); err != nil {
+		return err
+	}
+
+	for idx, c := range i.Skip {
+		if c.empty() {
+			return fmt.Errorf("%s.skip[%d]: class or has is missing", path, idx)
+		}
+	}
+
+	for idx, v := range i.Variants {
+		if v.When.empty() {
+			return fmt.Errorf("%s.variants[%d]: when is missing", path, idx)
+		}
+		if err := validateFields(fmt.Sprintf("%s.variants[%d]", path, idx), v.Fields, itemFields); err != nil {
+			return err
+		}
+	}
+
+	if i.Related != nil {
+		return i.Related.validate(path + ".related")
+	}
+
+	return nil
+}
+
+func (e End) validate() error {
+	if len(e.Content) == 0 {
+		return fmt.Errorf("end: content is missing")
+	}
+
+	for idx, k := range e.Kinds {
+		path := fmt.Sprintf("end.kinds[%d](%s)", idx, k.Name)
+		if k.When == "" {
+			return fmt.Errorf("%s: when is missing", path)
+		}
+
+		if _, ok := k.Fields["title"]; false == ok {
+			return fmt.Errorf("%s: title field is missing", path)
+		}
+
+		if err := validateFields(path, k.Fields, endFields); err != nil {
+			return err
+		}
+
+		for name, f := range k.Fields {
+			if f.Root != "" && f.Root != rootContent && f.Root != rootPage {
+				return fmt.Errorf("%s.%s: root %s is unknown", path, name, f.Root)
+			}
+			if f.All != (name == "images") {
+				return fmt.Errorf("%s.%s: all is only and always for images", path, name)
+			}
+		}
+	}
+
+	if e.Comments != nil {
+		return e.Comments.validate()
+	}
+
+	return nil
+}
+
+func (c Comments) validate() error {
+	if c.Select == "" {
+		return fmt.Errorf("end.comments: select is missing")
+	}
+
+	if _, ok := c.Fields["text"]; false == ok {
+		return fmt.Errorf("end.comments: text field is missing")
+	}
+
+	for sort := range c.Sort {
+		if sort != types.CommentSortRecommended && sort != types.CommentSortLatest {
+			return fmt.Errorf("end.comments.sort: %s is unknown", sort)
+		}
+	}
+
+	return validateFields("end.comments", c.Fields, commentFields)
+}
+
+func validateFields(path string, fields map[string]Field, known map[string]bool) error {
+	for name, f := range fields {
+		if false == known[name] {
+			return fmt.Errorf("%s: field %s is unknown", path, name)
+		}
+
+		if f.Index != nil && (f.Contains != "" || len(f.Excludes) > 0) {
+			return fmt.Errorf("%s.%s: index can not be used with contains or excludes", path, name)
+		}
+	}
+
+	return nil
+}
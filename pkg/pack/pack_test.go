@@ -0,0 +1,122 @@
+package pack
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const minimal = `
+name: daum/pc
+schema: 1
+version: 7
+home:
+  blocks:
+  - name: issue
+    section: {id: issue, label: 이슈}
+    select: ["#cSub ul[class=list_issue]"]
+    items:
+    - select: li
+      fields:
+        url: {select: [a], attr: href}
+        title: {select: [a]}
+end:
+  content: ["div[id=daumContent]"]
+`
+
+var _ = Describe("pack", func() {
+	It("parses every built-in pack", func() {
+		for _, name := range Names() {
+			p, err := Load(name, "")
+			Expect(err).ShouldNot(HaveOccurred(), name)
+			Expect(p.Name).Should(Equal(name))
+		}
+	})
+
+	It("shares items through yaml anchors", func() {
+		p, err := Load("daum/pc", "")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		blocks := p.Home.Blocks
+		Expect(blocks[3].Items[0].Select).Should(Equal(blocks[2].Items[0].Select))
+		Expect(blocks[4].Items[0].Select).Should(Equal("ul li"))
+		Expect(blocks[4].Items[0].Fields).Should(HaveKey("publisher"))
+		Expect(blocks[3].Screenshot).Should(Equal(blocks[2].Screenshot))
+		Expect(blocks[2].Screenshot.Offset).Should(BeZero())
+	})
+
+	Context("with override directory", func() {
+		var dir string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "pack")
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			Expect(os.RemoveAll(dir)).Should(Succeed())
+		})
+
+		write := func(name, data string) {
+			Expect(ioutil.WriteFile(filepath.Join(dir, FileName(name)), []byte(data), 0644)).Should(Succeed())
+		}
+
+		It("takes the pack of the directory", func() {
+			write("daum/pc", minimal)
+
+			p, err := Load("daum/pc", dir)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(p.Version).Should(Equal(7))
+			Expect(p.Home.Blocks).Should(HaveLen(1))
+		})
+
+		It("falls back to the built-in pack", func() {
+			p, err := Load("daum/mobile", dir)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(p.Name).Should(Equal("daum/mobile"))
+		})
+
+		It("refuses a pack of another collector", func() {
+			write("daum/mobile", minimal)
+
+			_, err := Load("daum/mobile", dir)
+			Expect(err).Should(MatchError(ContainSubstring("is for daum/pc")))
+		})
+
+		It("refuses a broken pack instead of falling back", func() {
+			write("daum/pc", strings.Replace(minimal, "schema: 1", "schema: 2", 1))
+
+			_, err := Load("daum/pc", dir)
+			Expect(err).Should(MatchError(ContainSubstring("schema 2")))
+		})
+	})
+
+	It("refuses invalid packs", func() {
+		invalids := []struct{ from, to, message string }{
+			{"version: 7", "version: 7\nfoo: bar", "field foo not found"},
+			{"title: {select: [a]}", "headline: {select: [a]}", "field headline is unknown"},
+			{"url: {select: [a], attr: href}", "image: {select: [img], attr: src}", "url field is missing"},
+			{"select: [\"#cSub", "order: random\n    select: [\"#cSub", "order random is unknown"},
+			{"select: [\"#cSub", "order: group\n    select: [\"#cSub", "needs groups"},
+			{"section: {id: issue, label: 이슈}", "section: {label: 이슈}", "section id is missing"},
+			{"    section: {id: issue, label: 이슈}\n", "", "section is missing"},
+			{"select: [\"#cSub", "panels: div.panel\n    select: [\"#cSub", "panels needs tabs"},
+			{"select: [\"#cSub", "demographic: {}\n    select: [\"#cSub", "demographic needs tabs or groups"},
+			{`content: ["div[id=daumContent]"]`, "content: []", "content is missing"},
+			{`content: ["div[id=daumContent]"]`, `content: ["div[id=daumContent]"]
+  comments: {select: li, fields: {author: {select: [a]}}}`, "text field is missing"},
+			{`content: ["div[id=daumContent]"]`, `content: ["div[id=daumContent]"]
+  comments: {select: li, sort: {oldest: [a]}, fields: {text: {}}}`, "oldest is unknown"},
+		}
+
+		for _, invalid := range invalids {
+			_, err := Parse([]byte(strings.Replace(minimal, invalid.from, invalid.to, 1)))
+			Expect(err).Should(MatchError(ContainSubstring(invalid.message)), invalid.to)
+		}
+	})
+})
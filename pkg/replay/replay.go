@@ -0,0 +1,103 @@
+package replay
+
+import (
+	"log"
+	"net/http"
+	"net/http/cookiejar"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// Session hijacks requests of a browser to record or replay them until closed.
+type Session struct {
+	router *rod.HijackRouter
+	store  *Store
+}
+
+// Close stops hijacking and writes the index of the responses recorded.
+func (s *Session) Close() error {
+	if err := s.router.Stop(); err != nil {
+		log.Println("failed to stop hijacking requests for", err)
+	}
+
+	return s.store.Flush()
+}
+
+// Record loads every request of the browser by itself and saves the responses to dir before
+// handing them to the browser. The index of the responses is written when the session is closed.
+func Record(browser *rod.Browser, dir string) (*Session, error) {
+	s, err := Open(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &http.Client{Jar: jar}
+
+	router := browser.HijackRequests()
+	router.MustAdd("*", func(ctx *rod.Hijack) {
+		method := ctx.Request.Method()
+		rawURL := ctx.Request.URL().String()
+
+		// let the client decompress the body, recordings are served back without content encoding
+		ctx.Request.Req().Header.Del("Accept-Encoding")
+
+		if err := ctx.LoadResponse(client, true); err != nil {
+			log.Println("failed to load response to record for", method, rawURL, err)
+			ctx.Response.Fail(proto.NetworkErrorReasonFailed)
+			return
+		}
+
+		payload := ctx.Response.Payload()
+		if err := s.Put(method, rawURL, payload.ResponseCode, ctx.Response.Headers(), payload.Body); err != nil {
+			log.Println("failed to record response of", method, rawURL, err)
+		}
+	})
+
+	go router.Run()
+
+	log.Println("record responses to", dir)
+
+	return &Session{router: router, store: s}, nil
+}
+
+// Replay serves the browser with the responses recorded in dir. Requests never recorded fail
+// as if the network is disconnected, so nothing leaks to the live sites.
+func Replay(browser *rod.Browser, dir string) (*Session, error) {
+	s, err := Open(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	router := browser.HijackRequests()
+	router.MustAdd("*", func(ctx *rod.Hijack) {
+		method := ctx.Request.Method()
+		rawURL := ctx.Request.URL().String()
+
+		e, body, ok := s.Get(method, rawURL)
+		if false == ok {
+			log.Println("no recorded response for", method, rawURL)
+			ctx.Response.Fail(proto.NetworkErrorReasonInternetDisconnected)
+			return
+		}
+
+		ctx.Response.Payload().ResponseCode = e.Status
+		for k, vs := range e.Header {
+			for _, v := range vs {
+				ctx.Response.SetHeader(k, v)
+			}
+		}
+		ctx.Response.SetBody(body)
+	})
+
+	go router.Run()
+
+	log.Printf("replay %d numbers of responses from %s\n", s.Len(), dir)
+
+	return &Session{router: router, store: s}, nil
+}
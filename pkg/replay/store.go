@@ -0,0 +1,153 @@
+package replay
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+const (
+	indexFile = "index.json"
+	bodyDir   = "body"
+)
+
+// Entry is a recorded response of a single request.
+type Entry struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Status int         `json:"status"`
+	Header http.Header `json:"header"`
+	Body   string      `json:"body"`
+}
+
+// Store keeps recorded responses as an index file and a body file per request under a directory. Bodies are
+// written as they are put, while the index is written once by Flush.
+type Store struct {
+	dir string
+
+	mu      sync.Mutex
+	entries map[string]Entry
+	dirty   bool
+}
+
+func Open(dir string) (*Store, error) {
+	s := &Store{dir: dir, entries: make(map[string]Entry)}
+
+	if err := os.MkdirAll(filepath.Join(dir, bodyDir), os.ModePerm); err != nil {
+		return nil, err
+	}
+
+	byteArr, err := ioutil.ReadFile(filepath.Join(dir, indexFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, err
+	}
+
+	entries := make([]Entry, 0)
+	if err = json.Unmarshal(byteArr, &entries); err != nil {
+		return nil, fmt.Errorf("failed to load recordings index of %s for %v", dir, err)
+	}
+
+	for _, e := range entries {
+		s.add(e)
+	}
+
+	return s, nil
+}
+
+func (s *Store) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.entries)
+}
+
+// Put saves the body of a response and keeps its entry until Flush.
+func (s *Store) Put(method, rawURL string, status int, header http.Header, body []byte) error {
+	key := toKey(method, rawURL)
+	e := Entry{Method: method, URL: rawURL, Status: status, Header: header, Body: filepath.Join(bodyDir, key)}
+
+	if err := ioutil.WriteFile(filepath.Join(s.dir, e.Body), body, 0644); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.add(e)
+	s.dirty = true
+
+	return nil
+}
+
+// Flush writes the index of responses put since opened, sorted by url and method so recordings diff well.
+func (s *Store) Flush() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if false == s.dirty {
+		return nil
+	}
+
+	entries := make([]Entry, 0, len(s.entries))
+	for _, e := range s.entries {
+		entries = append(entries, e)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].URL != entries[j].URL {
+			return entries[i].URL < entries[j].URL
+		}
+		return entries[i].Method < entries[j].Method
+	})
+
+	byteArr, err := json.MarshalIndent(entries, "", " ")
+	if err != nil {
+		return err
+	}
+
+	if err = ioutil.WriteFile(filepath.Join(s.dir, indexFile), byteArr, 0644); err != nil {
+		return err
+	}
+
+	s.dirty = false
+
+	return nil
+}
+
+// Get finds the response recorded for the same method and url. A query often names the article, so requests
+// differing in query are not served the same response.
+func (s *Store) Get(method, rawURL string) (Entry, []byte, bool) {
+	s.mu.Lock()
+	e, ok := s.entries[toKey(method, rawURL)]
+	s.mu.Unlock()
+
+	if false == ok {
+		return Entry{}, nil, false
+	}
+
+	body, err := ioutil.ReadFile(filepath.Join(s.dir, e.Body))
+	if err != nil {
+		return Entry{}, nil, false
+	}
+
+	return e, body, true
+}
+
+func (s *Store) add(e Entry) {
+	s.entries[toKey(e.Method, e.URL)] = e
+}
+
+func toKey(method, rawURL string) string {
+	sum := sha1.Sum([]byte(method + " " + rawURL))
+	return hex.EncodeToString(sum[:])
+}
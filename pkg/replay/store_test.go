@@ -0,0 +1,67 @@
+package replay
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("recording store", func() {
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "replay")
+		Expect(err).Should(BeNil())
+	})
+
+	AfterEach(func() {
+		_ = os.RemoveAll(dir)
+	})
+
+	It("serves recorded response after flush and reopen", func() {
+		s, err := Open(dir)
+		Expect(err).Should(BeNil())
+
+		header := http.Header{"Content-Type": []string{"text/html"}}
+		err = s.Put("GET", "https://news.daum.net/", 200, header, []byte("<html></html>"))
+		Expect(err).Should(BeNil())
+
+		_, err = os.Stat(filepath.Join(dir, indexFile))
+		Expect(os.IsNotExist(err)).Should(BeTrue())
+
+		Expect(s.Flush()).Should(BeNil())
+
+		reopened, err := Open(dir)
+		Expect(err).Should(BeNil())
+		Expect(reopened.Len()).Should(Equal(1))
+
+		e, body, ok := reopened.Get("GET", "https://news.daum.net/")
+		Expect(ok).Should(BeTrue())
+		Expect(e.Status).Should(Equal(200))
+		Expect(e.Header.Get("Content-Type")).Should(Equal("text/html"))
+		Expect(string(body)).Should(Equal("<html></html>"))
+	})
+
+	It("serves only the same method and url", func() {
+		s, err := Open(dir)
+		Expect(err).Should(BeNil())
+
+		err = s.Put("GET", "https://www.press.co.kr/news/articleView.html?idxno=1", 200, http.Header{}, []byte("1"))
+		Expect(err).Should(BeNil())
+
+		_, body, ok := s.Get("GET", "https://www.press.co.kr/news/articleView.html?idxno=1")
+		Expect(ok).Should(BeTrue())
+		Expect(string(body)).Should(Equal("1"))
+
+		_, _, ok = s.Get("GET", "https://www.press.co.kr/news/articleView.html?idxno=2")
+		Expect(ok).Should(BeFalse())
+
+		_, _, ok = s.Get("POST", "https://www.press.co.kr/news/articleView.html?idxno=1")
+		Expect(ok).Should(BeFalse())
+	})
+})
@@ -0,0 +1,228 @@
+package revisit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/darimuri/coll-news/pkg/types"
+	"github.com/darimuri/coll-news/pkg/util"
+)
+
+const (
+	// Dir is the directory of the revisit queue and series under the save path of a source and type.
+	Dir       = "revisit"
+	QueueFile = "queue.json"
+	SeriesDir = "series"
+)
+
+// Entry is an article revisited until Window after it was first seen.
+type Entry struct {
+	URL         string    `json:"url"`
+	Title       string    `json:"title"`
+	FirstSeen   time.Time `json:"first_seen"`
+	LastVisited time.Time `json:"last_visited"`
+}
+
+// Queue is the articles to revisit by their normalized url.
+type Queue struct {
+	Entries map[string]*Entry `json:"entries"`
+}
+
+// Load reads the queue of path, which is empty when it is not saved yet.
+func Load(path string) (*Queue, error) {
+	q := &Queue{Entries: make(map[string]*Entry)}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return q, nil
+		}
+		return nil, err
+	}
+
+	if err = json.Unmarshal(data, q); err != nil {
+		return nil, fmt.Errorf("revisit queue %s is broken: %w", path, err)
+	}
+
+	if q.Entries == nil {
+		q.Entries = make(map[string]*Entry)
+	}
+
+	return q, nil
+}
+
+func (q *Queue) Save(path string) error {
+	data, err := json.Marshal(q)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), os.FileMode(0700)); err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	if err = ioutil.WriteFile(tmp, data, os.FileMode(0644)); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
+
+// Add queues news with ends seen at, and returns the ones which were not queued yet. News queued before
+// keep the time they were first seen.
+func (q *Queue) Add(news []types.News, at time.Time) []types.News {
+	added := make([]types.News, 0)
+
+	for _, n := range news {
+		if n.End == nil {
+			continue
+		}
+
+		key, err := util.NormalizeURL(n.URL)
+		if err != nil {
+			continue
+		}
+
+		if _, ok := q.Entries[key]; ok {
+			continue
+		}
+
+		q.Entries[key] = &Entry{URL: n.URL, Title: n.Title, FirstSeen: at, LastVisited: at}
+		added = append(added, n)
+	}
+
+	return added
+}
+
+// Due drops articles first seen more than window before now, and returns the ones last visited cadence or
+// more before now, the longest unvisited first.
+func (q *Queue) Due(now time.Time, window, cadence time.Duration) []Entry {
+	due := make([]Entry, 0)
+
+	for key, e := range q.Entries {
+		if now.Sub(e.FirstSeen) > window {
+			delete(q.Entries, key)
+			continue
+		}
+
+		if now.Sub(e.LastVisited) >= cadence {
+			due = append(due, *e)
+		}
+	}
+
+	sort.Slice(due, func(i, j int) bool {
+		if due[i].LastVisited.Equal(due[j].LastVisited) {
+			return due[i].URL < due[j].URL
+		}
+		return due[i].LastVisited.Before(due[j].LastVisited)
+	})
+
+	return due
+}
+
+// Visited records the article of url was revisited at.
+func (q *Queue) Visited(url string, at time.Time) {
+	key, err := util.NormalizeURL(url)
+	if err != nil {
+		return
+	}
+
+	if e, ok := q.Entries[key]; ok {
+		e.LastVisited = at
+	}
+}
+
+// Sample is engagement of an article at a time.
+type Sample struct {
+	URL        string          `json:"url"`
+	At         string          `json:"at"`
+	NumComment uint64          `json:"num_comment"`
+	Emotions   []types.Emotion `json:"emotions,omitempty"`
+	NumPlayed  uint64          `json:"num_played,omitempty"`
+}
+
+// NewSample takes engagement of the end of n, which was collected at unless the end tells when.
+func NewSample(n types.News, at time.Time) Sample {
+	s := Sample{URL: n.URL, At: at.Format(types.DataDateTimeFormat)}
+	if n.End == nil {
+		return s
+	}
+
+	if n.End.CollectedAt != "" {
+		s.At = n.End.CollectedAt
+	}
+
+	s.NumComment = n.End.NumComment
+	s.Emotions = n.End.Emotions
+	s.NumPlayed = n.End.NumPlayed
+
+	return s
+}
+
+// SeriesFile is the file of the time series of the article of url under dir, named by a hash of its normalized url.
+func SeriesFile(dir, url string) (string, error) {
+	hash, err := util.URLHash(url)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, hash+".jsonl"), nil
+}
+
+// Append adds s as a line to the time series of its article under dir.
+func Append(dir string, s Sample) error {
+	path, err := SeriesFile(dir, s.URL)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(dir, os.FileMode(0700)); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, os.FileMode(0644))
+	if err != nil {
+		return err
+	}
+
+	if _, err = f.Write(append(data, '\n')); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// ReadSeries reads samples of a time series file in the order they were appended.
+func ReadSeries(path string) ([]Sample, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	samples := make([]Sample, 0)
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		s := Sample{}
+		if err = json.Unmarshal(scanner.Bytes(), &s); err != nil {
+			return nil, fmt.Errorf("series %s is broken: %w", path, err)
+		}
+		samples = append(samples, s)
+	}
+
+	return samples, scanner.Err()
+}
@@ -0,0 +1,86 @@
+package revisit
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/darimuri/coll-news/pkg/types"
+)
+
+func article(url string, numComment uint64) types.News {
+	return types.News{URL: url, Title: url, End: &types.End{NumComment: numComment}}
+}
+
+var _ = Describe("revisit", func() {
+	var dir string
+	started := time.Date(2021, 10, 16, 4, 0, 0, 0, time.UTC)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "revisit")
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).Should(Succeed())
+	})
+
+	It("queues articles with ends once", func() {
+		q, err := Load(filepath.Join(dir, QueueFile))
+		Expect(err).ShouldNot(HaveOccurred())
+
+		noEnd := types.News{URL: "https://v.daum.net/v/3"}
+		added := q.Add([]types.News{article("https://v.daum.net/v/1?f=o", 1), noEnd}, started)
+		Expect(added).Should(HaveLen(1))
+
+		added = q.Add([]types.News{article("https://v.daum.net/v/1", 2), article("https://v.daum.net/v/2", 0)}, started.Add(time.Hour))
+		Expect(added).Should(HaveLen(1))
+		Expect(added[0].URL).Should(Equal("https://v.daum.net/v/2"))
+
+		Expect(q.Save(filepath.Join(dir, QueueFile))).Should(Succeed())
+
+		loaded, err := Load(filepath.Join(dir, QueueFile))
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(loaded.Entries).Should(HaveLen(2))
+		Expect(loaded.Entries["https://v.daum.net/v/1"].FirstSeen.Equal(started)).Should(BeTrue())
+	})
+
+	It("revisits articles at the cadence within the window", func() {
+		q := &Queue{Entries: make(map[string]*Entry)}
+		q.Add([]types.News{article("https://v.daum.net/v/1", 1)}, started)
+		q.Add([]types.News{article("https://v.daum.net/v/2", 1)}, started.Add(30*time.Minute))
+
+		due := q.Due(started.Add(time.Hour), 6*time.Hour, time.Hour)
+		Expect(due).Should(HaveLen(1))
+		Expect(due[0].URL).Should(Equal("https://v.daum.net/v/1"))
+
+		q.Visited(due[0].URL, started.Add(time.Hour))
+
+		due = q.Due(started.Add(90*time.Minute), 6*time.Hour, time.Hour)
+		Expect(due).Should(HaveLen(1))
+		Expect(due[0].URL).Should(Equal("https://v.daum.net/v/2"))
+
+		Expect(q.Due(started.Add(7*time.Hour), 6*time.Hour, time.Hour)).Should(HaveLen(0))
+		Expect(q.Entries).Should(HaveLen(0))
+	})
+
+	It("appends samples to the series of the article", func() {
+		for i, url := range []string{"https://v.daum.net/v/1", "https://v.daum.net/v/1?f=o", "https://v.daum.net/v/2"} {
+			Expect(Append(dir, NewSample(article(url, uint64(i)), started.Add(time.Duration(i)*time.Hour)))).Should(Succeed())
+		}
+
+		path, err := SeriesFile(dir, "https://v.daum.net/v/1")
+		Expect(err).ShouldNot(HaveOccurred())
+
+		samples, err := ReadSeries(path)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(samples).Should(HaveLen(2))
+		Expect(samples[1].NumComment).Should(Equal(uint64(1)))
+		Expect(samples[1].At).Should(Equal(started.Add(time.Hour).Format(types.DataDateTimeFormat)))
+	})
+})
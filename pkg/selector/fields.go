@@ -0,0 +1,74 @@
+package selector
+
+import (
+	"log"
+
+	"github.com/darimuri/coll-news/pkg/types"
+)
+
+// Fields reads fields of a single item. A field failed to read is left empty and recorded,
+// so the item is kept with what could be read instead of failing the whole list.
+type Fields struct {
+	prefix string
+	errs   []types.FieldError
+}
+
+// NewFields records errors with field names prefixed, e.g. "end." for fields of a news end.
+func NewFields(prefix string) *Fields {
+	return &Fields{prefix: prefix}
+}
+
+func (f *Fields) Add(field string, err error) {
+	if err == nil {
+		return
+	}
+
+	f.errs = append(f.errs, types.FieldError{Field: f.prefix + field, Error: err.Error()})
+}
+
+// Text reads text of selector under e, or of e itself for an empty selector.
+func (f *Fields) Text(e Element, field, selector string) string {
+	var text string
+	var err error
+
+	if selector == "" {
+		text, err = e.Text()
+	} else {
+		text, err = e.ElText(selector)
+	}
+	f.Add(field, err)
+
+	return text
+}
+
+// Attr reads attribute name of selector under e, or of e itself for an empty selector.
+func (f *Fields) Attr(e Element, field, selector, name string) string {
+	var value string
+	var err error
+
+	if selector == "" {
+		value, err = e.Attr(name)
+	} else {
+		value, err = e.ElAttr(selector, name)
+	}
+	f.Add(field, err)
+
+	return value
+}
+
+func (f *Fields) Errors() []types.FieldError {
+	return f.errs
+}
+
+// Append adds n to list with the field errors recorded while reading it. News without url can not
+// be followed to its end, so it is dropped with a log instead.
+func Append(list []types.News, n types.News, f *Fields) []types.News {
+	n.FieldErrors = append(n.FieldErrors, f.Errors()...)
+
+	if n.URL == "" {
+		log.Printf("skip news '%s' without url for %v\n", n.Title, n.FieldErrors)
+		return list
+	}
+
+	return append(list, n)
+}
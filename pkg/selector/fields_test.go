@@ -0,0 +1,52 @@
+package selector
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/darimuri/coll-news/pkg/types"
+)
+
+var _ = Describe("fields", func() {
+	page := NewPage(nil, "daum/mobile", "https://m.daum.net/")
+
+	It("keeps news with field errors", func() {
+		f := NewFields("")
+		f.Add("title", page.wrap("ul > li:nth(3) span.txt_cp", ErrNotFound))
+		f.Add("publisher", nil)
+
+		list := Append(nil, types.News{URL: "https://v.daum.net/v/1"}, f)
+
+		Expect(list).Should(HaveLen(1))
+		Expect(list[0].FieldErrors).Should(Equal([]types.FieldError{{
+			Field: "title",
+			Error: "daum/mobile: element is not found for 'ul > li:nth(3) span.txt_cp' in https://m.daum.net/",
+		}}))
+	})
+
+	It("drops news without url", func() {
+		f := NewFields("")
+		f.Add("url", page.wrap("a", ErrNoAttribute))
+
+		Expect(Append(nil, types.News{Title: "title"}, f)).Should(BeEmpty())
+	})
+
+	It("prefixes field names", func() {
+		f := NewFields("end.")
+		f.Add("author", errors.New("detached"))
+
+		Expect(f.Errors()).Should(ConsistOf(types.FieldError{Field: "end.author", Error: "detached"}))
+	})
+})
+
+var _ = Describe("error", func() {
+	It("unwraps the cause and is not wrapped twice", func() {
+		page := NewPage(nil, "daum/pc", "https://news.daum.net/")
+
+		err := page.wrap("#cMain", ErrNotFound)
+		Expect(errors.Is(err, ErrNotFound)).Should(BeTrue())
+		Expect(page.wrap("#cMain #mArticle", err)).Should(BeIdenticalTo(err))
+	})
+})
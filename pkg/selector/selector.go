@@ -0,0 +1,269 @@
+package selector
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	rt "github.com/darimuri/go-lib/rodtemplate"
+
+	"github.com/darimuri/coll-news/pkg/util"
+)
+
+var (
+	ErrNotFound    = errors.New("element is not found")
+	ErrNoAttribute = errors.New("attribute is not found")
+)
+
+var _ error = (*Error)(nil)
+
+// Error tells which selection failed, so a changed layout can be found without a stack dump.
+type Error struct {
+	Collector string
+	URL       string
+	Path      string
+	Err       error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s: %v for '%s' in %s", e.Collector, e.Err, e.Path, e.URL)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+type scope interface {
+	Has(selector string) bool
+	El(selector string) *rt.ElementTemplate
+	Els(selector string) rt.ElementsTemplate
+}
+
+// Page is where selections of a collector start. Selections return errors instead of panic.
+type Page struct {
+	*rt.PageTemplate
+	Collector string
+	URL       string
+}
+
+func NewPage(p *rt.PageTemplate, collector, url string) *Page {
+	return &Page{PageTemplate: p, Collector: collector, URL: url}
+}
+
+func (p *Page) Has(selector string) bool {
+	return has(p.PageTemplate, selector)
+}
+
+func (p *Page) El(selector string) (Element, error) {
+	return el(p, p.PageTemplate, "", selector)
+}
+
+func (p *Page) Els(selector string) []Element {
+	return els(p, p.PageTemplate, "", selector)
+}
+
+// First selects the first of selectors found in the page.
+func (p *Page) First(selectors ...string) (Element, error) {
+	return first(p, p.PageTemplate, "", selectors)
+}
+
+// Errorf makes an Error of the page for a failure not bound to a single selection.
+func (p *Page) Errorf(path string, format string, args ...interface{}) error {
+	return p.wrap(path, fmt.Errorf(format, args...))
+}
+
+func (p *Page) wrap(path string, err error) error {
+	var selErr *Error
+	if errors.As(err, &selErr) {
+		return err
+	}
+
+	return &Error{Collector: p.Collector, URL: p.URL, Path: path, Err: err}
+}
+
+// Element is a selected element which remembers the selector path from its page.
+type Element struct {
+	*rt.ElementTemplate
+
+	page *Page
+	path string
+}
+
+func (e Element) Path() string {
+	return e.path
+}
+
+func (e Element) Has(selector string) bool {
+	return has(e.ElementTemplate, selector)
+}
+
+func (e Element) El(selector string) (Element, error) {
+	return el(e.page, e.ElementTemplate, e.path, selector)
+}
+
+func (e Element) Els(selector string) []Element {
+	return els(e.page, e.ElementTemplate, e.path, selector)
+}
+
+// First selects the first of selectors found under the element.
+func (e Element) First(selectors ...string) (Element, error) {
+	return first(e.page, e.ElementTemplate, e.path, selectors)
+}
+
+// Errorf makes an Error of the element for a failure not bound to a single selection.
+func (e Element) Errorf(format string, args ...interface{}) error {
+	return e.page.Errorf(e.path, format, args...)
+}
+
+func (e Element) Text() (string, error) {
+	var text string
+	err := try(func() {
+		text = e.ElementTemplate.MustText()
+	})
+	if err != nil {
+		return "", e.page.wrap(e.path, err)
+	}
+
+	return strings.TrimSpace(text), nil
+}
+
+func (e Element) HTML() (string, error) {
+	var html string
+	err := try(func() {
+		html = e.ElementTemplate.MustHTML()
+	})
+	if err != nil {
+		return "", e.page.wrap(e.path, err)
+	}
+
+	return html, nil
+}
+
+func (e Element) Attr(name string) (string, error) {
+	var value *string
+	err := try(func() {
+		value = e.ElementTemplate.MustAttribute(name)
+	})
+	if err != nil {
+		return "", e.page.wrap(e.path, err)
+	}
+
+	if value == nil {
+		return "", e.page.wrap(fmt.Sprintf("%s[%s]", e.path, name), ErrNoAttribute)
+	}
+
+	return *value, nil
+}
+
+// Count reads a counter of the element such as "1,234".
+func (e Element) Count() (uint64, error) {
+	text, err := e.Text()
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := util.ParseCount(text)
+	if err != nil {
+		return 0, e.page.wrap(e.path, err)
+	}
+
+	return count, nil
+}
+
+// ElText is the text of the element selected by selector under e.
+func (e Element) ElText(selector string) (string, error) {
+	child, err := e.El(selector)
+	if err != nil {
+		return "", err
+	}
+
+	return child.Text()
+}
+
+// ElAttr is the attribute of the element selected by selector under e.
+func (e Element) ElAttr(selector, name string) (string, error) {
+	child, err := e.El(selector)
+	if err != nil {
+		return "", err
+	}
+
+	return child.Attr(name)
+}
+
+func has(s scope, selector string) (found bool) {
+	_ = try(func() {
+		found = s.Has(selector)
+	})
+
+	return
+}
+
+func el(p *Page, s scope, parent, selector string) (Element, error) {
+	path := join(parent, selector)
+
+	if false == has(s, selector) {
+		return Element{}, p.wrap(path, ErrNotFound)
+	}
+
+	var et *rt.ElementTemplate
+	if err := try(func() {
+		et = s.El(selector)
+	}); err != nil {
+		return Element{}, p.wrap(path, err)
+	}
+
+	return Element{ElementTemplate: et, page: p, path: path}, nil
+}
+
+func els(p *Page, s scope, parent, selector string) []Element {
+	path := join(parent, selector)
+
+	var ets rt.ElementsTemplate
+	_ = try(func() {
+		if s.Has(selector) {
+			ets = s.Els(selector)
+		}
+	})
+
+	elements := make([]Element, 0, len(ets))
+	for idx, et := range ets {
+		elements = append(elements, Element{ElementTemplate: et, page: p, path: fmt.Sprintf("%s:nth(%d)", path, idx)})
+	}
+
+	return elements
+}
+
+func first(p *Page, s scope, parent string, selectors []string) (Element, error) {
+	for _, selector := range selectors {
+		if has(s, selector) {
+			return el(p, s, parent, selector)
+		}
+	}
+
+	return Element{}, p.wrap(join(parent, strings.Join(selectors, ", ")), ErrNotFound)
+}
+
+func join(parent, selector string) string {
+	if parent == "" {
+		return selector
+	}
+
+	return parent + " " + selector
+}
+
+func try(f func()) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			switch t := v.(type) {
+			case error:
+				err = t
+			default:
+				err = fmt.Errorf("%v", t)
+			}
+		}
+	}()
+
+	f()
+
+	return nil
+}
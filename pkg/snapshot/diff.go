@@ -0,0 +1,154 @@
+package snapshot
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/darimuri/coll-news/pkg/types"
+	"github.com/darimuri/coll-news/pkg/util"
+)
+
+// Position is where a news is listed in a run.
+type Position struct {
+	Location types.Loc `json:"loc"`
+	NewsPage int       `json:"news_page"`
+	Order    int       `json:"order"`
+	SubOrder int       `json:"sub_order"`
+}
+
+func (p Position) String() string {
+	return fmt.Sprintf("%s p%d #%d.%d", p.Location, p.NewsPage, p.Order, p.SubOrder)
+}
+
+func (p Position) before(o Position) bool {
+	if p.Location != o.Location {
+		return p.Location == types.Top
+	}
+	if p.NewsPage != o.NewsPage {
+		return p.NewsPage < o.NewsPage
+	}
+	if p.Order != o.Order {
+		return p.Order < o.Order
+	}
+	return p.SubOrder < o.SubOrder
+}
+
+// Item is a news of a run by its normalized url, at the first position it is listed.
+type Item struct {
+	Key      string   `json:"key"`
+	URL      string   `json:"url"`
+	Title    string   `json:"title"`
+	Position Position `json:"position"`
+}
+
+// Move is an item listed at another position in the later run.
+type Move struct {
+	Item
+	From Position `json:"from"`
+}
+
+// Retitle is an item listed with another title in the later run.
+type Retitle struct {
+	Item
+	From string `json:"from"`
+}
+
+// Diff is what changed in news lists from run A to run B.
+type Diff struct {
+	A        string    `json:"a"`
+	B        string    `json:"b"`
+	NumA     int       `json:"num_a"`
+	NumB     int       `json:"num_b"`
+	Added    []Item    `json:"added"`
+	Removed  []Item    `json:"removed"`
+	Moved    []Move    `json:"moved"`
+	Retitled []Retitle `json:"retitled"`
+}
+
+// Compare diffs news of run a named nameA to news of run b named nameB, keyed by their normalized urls.
+func Compare(nameA string, a []types.News, nameB string, b []types.News) Diff {
+	itemsA, itemsB := items(a), items(b)
+
+	d := Diff{
+		A:        nameA,
+		B:        nameB,
+		NumA:     len(itemsA),
+		NumB:     len(itemsB),
+		Added:    make([]Item, 0),
+		Removed:  make([]Item, 0),
+		Moved:    make([]Move, 0),
+		Retitled: make([]Retitle, 0),
+	}
+
+	for key, ib := range itemsB {
+		ia, ok := itemsA[key]
+		if false == ok {
+			d.Added = append(d.Added, ib)
+			continue
+		}
+
+		if ia.Position != ib.Position {
+			d.Moved = append(d.Moved, Move{Item: ib, From: ia.Position})
+		}
+
+		if ia.Title != ib.Title {
+			d.Retitled = append(d.Retitled, Retitle{Item: ib, From: ia.Title})
+		}
+	}
+
+	for key, ia := range itemsA {
+		if _, ok := itemsB[key]; false == ok {
+			d.Removed = append(d.Removed, ia)
+		}
+	}
+
+	sortItems(d.Added)
+	sortItems(d.Removed)
+	sort.Slice(d.Moved, func(i, j int) bool { return d.Moved[i].Position.before(d.Moved[j].Position) })
+	sort.Slice(d.Retitled, func(i, j int) bool { return d.Retitled[i].Position.before(d.Retitled[j].Position) })
+
+	return d
+}
+
+// items keys news by their normalized urls. A news listed more than once is kept at its first position.
+func items(news []types.News) map[string]Item {
+	byKey := make(map[string]Item)
+
+	for _, n := range news {
+		key, err := util.NormalizeURL(n.URL)
+		if err != nil {
+			continue
+		}
+
+		p := Position{Location: n.Location, NewsPage: n.NewsPage, Order: n.Order, SubOrder: n.SubOrder}
+		if listed, ok := byKey[key]; ok && false == p.before(listed.Position) {
+			continue
+		}
+
+		byKey[key] = Item{Key: key, URL: n.URL, Title: strings.TrimSpace(n.Title), Position: p}
+	}
+
+	return byKey
+}
+
+// Ranked is news of a run by their normalized urls in the order they are listed, Top before Home.
+func Ranked(news []types.News) []Item {
+	ranked := make([]Item, 0)
+	for _, item := range items(news) {
+		ranked = append(ranked, item)
+	}
+
+	sortItems(ranked)
+
+	return ranked
+}
+
+func sortItems(items []Item) {
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Position == items[j].Position {
+			return items[i].Key < items[j].Key
+		}
+		return items[i].Position.before(items[j].Position)
+	})
+}
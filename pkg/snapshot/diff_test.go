@@ -0,0 +1,63 @@
+package snapshot
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/darimuri/coll-news/pkg/types"
+)
+
+func listed(url, title string, loc types.Loc, order int) types.News {
+	return types.News{URL: url, Title: title, Location: loc, NewsPage: 1, Order: order}
+}
+
+var _ = Describe("diff", func() {
+	a := []types.News{
+		listed("https://v.daum.net/v/1?f=o", "one", types.Top, 1),
+		listed("https://v.daum.net/v/2", "two", types.Top, 2),
+		listed("https://v.daum.net/v/3", "three", types.Top, 3),
+	}
+	b := []types.News{
+		listed("https://v.daum.net/v/4", "four", types.Top, 1),
+		listed("https://v.daum.net/v/1?f=m", "one", types.Top, 2),
+		listed("https://v.daum.net/v/2", "two | edited", types.Top, 3),
+		listed("https://v.daum.net/v/1", "one", types.Home, 1),
+	}
+
+	It("reports added, removed, moved and retitled news by normalized url", func() {
+		d := Compare("a", a, "b", b)
+
+		Expect(d.NumA).Should(Equal(3))
+		Expect(d.NumB).Should(Equal(3))
+
+		Expect(d.Added).Should(HaveLen(1))
+		Expect(d.Added[0].Key).Should(Equal("https://v.daum.net/v/4"))
+
+		Expect(d.Removed).Should(HaveLen(1))
+		Expect(d.Removed[0].Key).Should(Equal("https://v.daum.net/v/3"))
+
+		Expect(d.Moved).Should(HaveLen(2))
+		Expect(d.Moved[0].Key).Should(Equal("https://v.daum.net/v/1"))
+		Expect(d.Moved[0].From.Order).Should(Equal(1))
+		Expect(d.Moved[0].Position.Order).Should(Equal(2))
+
+		Expect(d.Retitled).Should(HaveLen(1))
+		Expect(d.Retitled[0].From).Should(Equal("two"))
+		Expect(d.Retitled[0].Title).Should(Equal("two | edited"))
+	})
+
+	It("formats a diff", func() {
+		d := Compare("a", a, "b", b)
+
+		text, err := Format(d, FormatText)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(text).Should(ContainSubstring("~ Top p1 #1.0 -> Top p1 #2.0\tone\thttps://v.daum.net/v/1?f=m\n"))
+
+		md, err := Format(d, FormatMD)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(md).Should(ContainSubstring("|Top p1 #3.0|two|two \\| edited|https://v.daum.net/v/2|\n"))
+
+		_, err = Format(d, "xml")
+		Expect(err).Should(HaveOccurred())
+	})
+})
@@ -0,0 +1,93 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	FormatsDesc = "text/md/json"
+
+	FormatText = "text"
+	FormatMD   = "md"
+	FormatJSON = "json"
+)
+
+// Format renders d as text, a markdown document or json.
+func Format(d Diff, format string) (string, error) {
+	switch format {
+	case FormatText:
+		return formatText(d), nil
+	case FormatMD:
+		return formatMD(d), nil
+	case FormatJSON:
+		data, err := json.MarshalIndent(d, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(data) + "\n", nil
+	}
+
+	return "", fmt.Errorf("diff format %s is not supported", format)
+}
+
+func formatText(d Diff) string {
+	sb := strings.Builder{}
+
+	sb.WriteString(fmt.Sprintf("%s(%d news) -> %s(%d news)\n", d.A, d.NumA, d.B, d.NumB))
+
+	sb.WriteString(fmt.Sprintf("\nadded %d\n", len(d.Added)))
+	for _, i := range d.Added {
+		sb.WriteString(fmt.Sprintf("+ %s\t%s\t%s\n", i.Position, i.Title, i.URL))
+	}
+
+	sb.WriteString(fmt.Sprintf("\nremoved %d\n", len(d.Removed)))
+	for _, i := range d.Removed {
+		sb.WriteString(fmt.Sprintf("- %s\t%s\t%s\n", i.Position, i.Title, i.URL))
+	}
+
+	sb.WriteString(fmt.Sprintf("\nmoved %d\n", len(d.Moved)))
+	for _, m := range d.Moved {
+		sb.WriteString(fmt.Sprintf("~ %s -> %s\t%s\t%s\n", m.From, m.Position, m.Title, m.URL))
+	}
+
+	sb.WriteString(fmt.Sprintf("\nretitled %d\n", len(d.Retitled)))
+	for _, r := range d.Retitled {
+		sb.WriteString(fmt.Sprintf("* %s\t%s -> %s\t%s\n", r.Position, r.From, r.Title, r.URL))
+	}
+
+	return sb.String()
+}
+
+func formatMD(d Diff) string {
+	sb := strings.Builder{}
+
+	sb.WriteString(fmt.Sprintf("## %s(%d news) -> %s(%d news)\n", d.A, d.NumA, d.B, d.NumB))
+
+	sb.WriteString(fmt.Sprintf("\n### Added %d\n\n|Position|Title|URL|\n|---|---|---|\n", len(d.Added)))
+	for _, i := range d.Added {
+		sb.WriteString(fmt.Sprintf("|%s|%s|%s|\n", i.Position, escapeMD(i.Title), i.URL))
+	}
+
+	sb.WriteString(fmt.Sprintf("\n### Removed %d\n\n|Position|Title|URL|\n|---|---|---|\n", len(d.Removed)))
+	for _, i := range d.Removed {
+		sb.WriteString(fmt.Sprintf("|%s|%s|%s|\n", i.Position, escapeMD(i.Title), i.URL))
+	}
+
+	sb.WriteString(fmt.Sprintf("\n### Moved %d\n\n|From|To|Title|URL|\n|---|---|---|---|\n", len(d.Moved)))
+	for _, m := range d.Moved {
+		sb.WriteString(fmt.Sprintf("|%s|%s|%s|%s|\n", m.From, m.Position, escapeMD(m.Title), m.URL))
+	}
+
+	sb.WriteString(fmt.Sprintf("\n### Retitled %d\n\n|Position|Before|After|URL|\n|---|---|---|---|\n", len(d.Retitled)))
+	for _, r := range d.Retitled {
+		sb.WriteString(fmt.Sprintf("|%s|%s|%s|%s|\n", r.Position, escapeMD(r.From), escapeMD(r.Title), r.URL))
+	}
+
+	return sb.String()
+}
+
+func escapeMD(s string) string {
+	return strings.ReplaceAll(s, "|", "\\|")
+}
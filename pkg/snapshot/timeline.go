@@ -0,0 +1,339 @@
+package snapshot
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/darimuri/coll-news/pkg/types"
+	"github.com/darimuri/coll-news/pkg/util"
+)
+
+const dayFormat = "2006-01-02"
+
+// Span is when an article was listed, in how many runs.
+type Span struct {
+	FirstSeen time.Time `json:"first_seen"`
+	LastSeen  time.Time `json:"last_seen"`
+	Runs      int       `json:"runs"`
+}
+
+func (s *Span) see(at time.Time) {
+	if s.Runs == 0 || at.Before(s.FirstSeen) {
+		s.FirstSeen = at
+	}
+	if at.After(s.LastSeen) {
+		s.LastSeen = at
+	}
+	s.Runs++
+}
+
+// Stayed is the time from the first run to the last run an article was listed in.
+func (s Span) Stayed() time.Duration {
+	return s.LastSeen.Sub(s.FirstSeen)
+}
+
+// Point is the position of an article in a run.
+type Point struct {
+	At       time.Time `json:"at"`
+	Position Position  `json:"position"`
+}
+
+// Trajectory is how an article went through the lists of runs, at its first position of every run.
+type Trajectory struct {
+	Span
+	Key      string   `json:"key"`
+	URL      string   `json:"url"`
+	Title    string   `json:"title"`
+	Stayed   string   `json:"stayed"`
+	Top      *Span    `json:"top,omitempty"`
+	Home     *Span    `json:"home,omitempty"`
+	Best     Position `json:"best"`
+	Worst    Position `json:"worst"`
+	Sections []string `json:"sections"`
+	Points   []Point  `json:"points"`
+}
+
+// Day summarizes articles listed by runs of a day.
+type Day struct {
+	Date      string `json:"date"`
+	Runs      int    `json:"runs"`
+	Articles  int    `json:"articles"`
+	New       int    `json:"new"`
+	AvgStayed string `json:"avg_stayed"`
+}
+
+// Timeline collects trajectories of articles from news of runs.
+type Timeline struct {
+	trajectories map[string]*Trajectory
+	runsOfDay    map[string]int
+	keysOfDay    map[string]map[string]bool
+}
+
+func NewTimeline() *Timeline {
+	return &Timeline{
+		trajectories: make(map[string]*Trajectory),
+		runsOfDay:    make(map[string]int),
+		keysOfDay:    make(map[string]map[string]bool),
+	}
+}
+
+// Add takes news of a run started at. Runs are expected to be added the earliest first.
+func (tl *Timeline) Add(started time.Time, news []types.News) {
+	day := started.Format(dayFormat)
+	tl.runsOfDay[day]++
+	if _, ok := tl.keysOfDay[day]; false == ok {
+		tl.keysOfDay[day] = make(map[string]bool)
+	}
+
+	sections := make(map[string][]string)
+	for _, n := range news {
+		key, err := util.NormalizeURL(n.URL)
+		if err != nil || n.Section.ID == "" {
+			continue
+		}
+		sections[key] = append(sections[key], n.Section.ID)
+	}
+
+	seenLoc := make(map[string]bool)
+	for _, n := range news {
+		key, err := util.NormalizeURL(n.URL)
+		if err != nil {
+			continue
+		}
+
+		t := tl.trajectory(key, n)
+		locKey := key + "|" + string(n.Location)
+		if false == seenLoc[locKey] {
+			seenLoc[locKey] = true
+			switch n.Location {
+			case types.Top:
+				t.Top = seeSpan(t.Top, started)
+			case types.Home:
+				t.Home = seeSpan(t.Home, started)
+			}
+		}
+	}
+
+	for key, item := range items(news) {
+		t := tl.trajectories[key]
+		t.see(started)
+		t.Title = item.Title
+		t.Points = append(t.Points, Point{At: started, Position: item.Position})
+
+		if t.Runs == 1 || item.Position.before(t.Best) {
+			t.Best = item.Position
+		}
+		if t.Runs == 1 || t.Worst.before(item.Position) {
+			t.Worst = item.Position
+		}
+
+		for _, id := range sections[key] {
+			if false == contains(t.Sections, id) {
+				t.Sections = append(t.Sections, id)
+			}
+		}
+
+		tl.keysOfDay[day][key] = true
+	}
+}
+
+func (tl *Timeline) trajectory(key string, n types.News) *Trajectory {
+	t, ok := tl.trajectories[key]
+	if false == ok {
+		t = &Trajectory{Key: key, URL: n.URL, Sections: make([]string, 0), Points: make([]Point, 0)}
+		tl.trajectories[key] = t
+	}
+
+	return t
+}
+
+func seeSpan(s *Span, at time.Time) *Span {
+	if s == nil {
+		s = &Span{}
+	}
+	s.see(at)
+
+	return s
+}
+
+// Trajectories are the articles of the timeline, the first listed first.
+func (tl *Timeline) Trajectories() []Trajectory {
+	trajectories := make([]Trajectory, 0, len(tl.trajectories))
+	for _, t := range tl.trajectories {
+		t.Stayed = t.Span.Stayed().String()
+		trajectories = append(trajectories, *t)
+	}
+
+	sort.Slice(trajectories, func(i, j int) bool {
+		ti, tj := trajectories[i], trajectories[j]
+		if false == ti.FirstSeen.Equal(tj.FirstSeen) {
+			return ti.FirstSeen.Before(tj.FirstSeen)
+		}
+		if ti.Best != tj.Best {
+			return ti.Best.before(tj.Best)
+		}
+		return ti.Key < tj.Key
+	})
+
+	return trajectories
+}
+
+// Days summarize the timeline by day. Articles first listed in a day are New of it, with their average stay.
+func (tl *Timeline) Days() []Day {
+	days := make([]Day, 0, len(tl.runsOfDay))
+	for date, runs := range tl.runsOfDay {
+		d := Day{Date: date, Runs: runs, Articles: len(tl.keysOfDay[date])}
+
+		stayed := time.Duration(0)
+		for _, t := range tl.trajectories {
+			if t.FirstSeen.Format(dayFormat) == date {
+				d.New++
+				stayed += t.Span.Stayed()
+			}
+		}
+
+		if d.New > 0 {
+			stayed /= time.Duration(d.New)
+		}
+		d.AvgStayed = stayed.Round(time.Second).String()
+
+		days = append(days, d)
+	}
+
+	sort.Slice(days, func(i, j int) bool { return days[i].Date < days[j].Date })
+
+	return days
+}
+
+// FormatTimeline renders trajectories and days of tl as text tables, markdown tables or json.
+func FormatTimeline(tl *Timeline, format string) (string, error) {
+	trajectories, days := tl.Trajectories(), tl.Days()
+
+	switch format {
+	case FormatText:
+		return formatTimelineText(trajectories, days)
+	case FormatMD:
+		return formatTimelineMD(trajectories, days), nil
+	case FormatJSON:
+		data, err := json.MarshalIndent(struct {
+			Trajectories []Trajectory `json:"trajectories"`
+			Days         []Day        `json:"days"`
+		}{trajectories, days}, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(data) + "\n", nil
+	}
+
+	return "", fmt.Errorf("timeline format %s is not supported", format)
+}
+
+var (
+	trajectoryHeader = []string{"FirstSeen", "LastSeen", "Stayed", "Runs", "Top", "Home", "Best", "Worst", "Sections", "Title", "URL"}
+	dayHeader        = []string{"Date", "Runs", "Articles", "New", "AvgStayed"}
+)
+
+func trajectoryRow(t Trajectory) []string {
+	return []string{
+		t.FirstSeen.Format(types.DataDateTimeFormat),
+		t.LastSeen.Format(types.DataDateTimeFormat),
+		t.Stayed,
+		fmt.Sprint(t.Runs),
+		spanToString(t.Top),
+		spanToString(t.Home),
+		t.Best.String(),
+		t.Worst.String(),
+		strings.Join(t.Sections, ","),
+		t.Title,
+		t.URL,
+	}
+}
+
+func dayRow(d Day) []string {
+	return []string{d.Date, fmt.Sprint(d.Runs), fmt.Sprint(d.Articles), fmt.Sprint(d.New), d.AvgStayed}
+}
+
+func spanToString(s *Span) string {
+	if s == nil {
+		return "-"
+	}
+
+	return fmt.Sprintf("%s(%d)", s.FirstSeen.Format(types.DataDateTimeFormat), s.Runs)
+}
+
+func formatTimelineText(trajectories []Trajectory, days []Day) (string, error) {
+	buf := &bytes.Buffer{}
+	w := tabwriter.NewWriter(buf, 0, 4, 2, ' ', 0)
+
+	rows := [][]string{trajectoryHeader}
+	for _, t := range trajectories {
+		rows = append(rows, trajectoryRow(t))
+	}
+	rows = append(rows, nil, dayHeader)
+	for _, d := range days {
+		rows = append(rows, dayRow(d))
+	}
+
+	for _, row := range rows {
+		if _, err := fmt.Fprintln(w, strings.Join(row, "\t")); err != nil {
+			return "", err
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+func formatTimelineMD(trajectories []Trajectory, days []Day) string {
+	sb := strings.Builder{}
+
+	sb.WriteString("### Articles\n\n")
+	writeMDRow(&sb, trajectoryHeader)
+	writeMDRow(&sb, mdHeaderLine(len(trajectoryHeader)))
+	for _, t := range trajectories {
+		writeMDRow(&sb, trajectoryRow(t))
+	}
+
+	sb.WriteString("\n### Days\n\n")
+	writeMDRow(&sb, dayHeader)
+	writeMDRow(&sb, mdHeaderLine(len(dayHeader)))
+	for _, d := range days {
+		writeMDRow(&sb, dayRow(d))
+	}
+
+	return sb.String()
+}
+
+func writeMDRow(sb *strings.Builder, row []string) {
+	for _, col := range row {
+		sb.WriteString("|" + escapeMD(col))
+	}
+	sb.WriteString("|\n")
+}
+
+func mdHeaderLine(n int) []string {
+	line := make([]string, n)
+	for i := range line {
+		line[i] = "---"
+	}
+
+	return line
+}
+
+func contains(ids []string, id string) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,64 @@
+package snapshot
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/darimuri/coll-news/pkg/types"
+)
+
+var _ = Describe("timeline", func() {
+	started := time.Date(2021, 10, 16, 23, 0, 0, 0, time.UTC)
+
+	inSection := func(n types.News, id string) types.News {
+		n.Section = types.Section{ID: id}
+		return n
+	}
+
+	It("follows articles through runs", func() {
+		tl := NewTimeline()
+		tl.Add(started, []types.News{
+			inSection(listed("https://v.daum.net/v/1", "one", types.Top, 3), "headline"),
+			inSection(listed("https://v.daum.net/v/1?f=o", "one", types.Home, 1), "issue"),
+		})
+		tl.Add(started.Add(time.Hour), []types.News{
+			inSection(listed("https://v.daum.net/v/1", "one edited", types.Top, 1), "headline"),
+			inSection(listed("https://v.daum.net/v/2", "two", types.Home, 2), "popular"),
+		})
+		tl.Add(started.Add(2*time.Hour), []types.News{
+			inSection(listed("https://v.daum.net/v/2", "two", types.Home, 5), "popular"),
+		})
+
+		trajectories := tl.Trajectories()
+		Expect(trajectories).Should(HaveLen(2))
+
+		one := trajectories[0]
+		Expect(one.Key).Should(Equal("https://v.daum.net/v/1"))
+		Expect(one.Title).Should(Equal("one edited"))
+		Expect(one.Runs).Should(Equal(2))
+		Expect(one.Stayed).Should(Equal("1h0m0s"))
+		Expect(one.Top.Runs).Should(Equal(2))
+		Expect(one.Home.Runs).Should(Equal(1))
+		Expect(one.Best.Order).Should(Equal(1))
+		Expect(one.Worst.Order).Should(Equal(3))
+		Expect(one.Sections).Should(Equal([]string{"headline", "issue"}))
+		Expect(one.Points).Should(HaveLen(2))
+
+		two := trajectories[1]
+		Expect(two.Top).Should(BeNil())
+		Expect(two.Worst.Order).Should(Equal(5))
+
+		days := tl.Days()
+		Expect(days).Should(HaveLen(2))
+		Expect(days[0]).Should(Equal(Day{Date: "2021-10-16", Runs: 1, Articles: 1, New: 1, AvgStayed: "1h0m0s"}))
+		Expect(days[1]).Should(Equal(Day{Date: "2021-10-17", Runs: 2, Articles: 2, New: 1, AvgStayed: "1h0m0s"}))
+
+		for _, format := range []string{FormatText, FormatMD, FormatJSON} {
+			out, err := FormatTimeline(tl, format)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(out).Should(ContainSubstring("https://v.daum.net/v/2"))
+		}
+	})
+})
@@ -0,0 +1,47 @@
+package store
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/darimuri/coll-news/pkg/types"
+)
+
+// Failure records why a collection failed. It is written next to the list files the run would have written.
+type Failure struct {
+	Source              string `json:"source"`
+	Type                string `json:"type"`
+	Started             string `json:"started"`
+	Failed              string `json:"failed"`
+	Error               string `json:"error"`
+	ConsecutiveFailures int    `json:"consecutive_failures"`
+}
+
+func (r Run) FailureFile() string {
+	return filepath.Join(r.ListPath(), r.FilePrefix()+".error.json")
+}
+
+func WriteFailure(r Run, source, collectType string, consecutiveFailures int, err error) error {
+	f := Failure{
+		Source:              source,
+		Type:                collectType,
+		Started:             r.Started.Format(types.DataDateTimeFormat),
+		Failed:              time.Now().Format(types.DataDateTimeFormat),
+		Error:               err.Error(),
+		ConsecutiveFailures: consecutiveFailures,
+	}
+
+	byteArr, errJson := json.MarshalIndent(f, "", "  ")
+	if errJson != nil {
+		return errJson
+	}
+
+	if errMkdir := os.MkdirAll(r.ListPath(), os.FileMode(0700)); errMkdir != nil {
+		return errMkdir
+	}
+
+	return ioutil.WriteFile(r.FailureFile(), byteArr, os.FileMode(0644))
+}
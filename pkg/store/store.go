@@ -0,0 +1,349 @@
+package store
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/darimuri/coll-news/pkg/types"
+)
+
+const (
+	ListTypesDesc = "t(tsv)/m(markdown table)/b(both)"
+
+	ListTypeBoth = "b"
+	ListTypeTsv  = "t"
+	ListTypeMD   = "m"
+
+	filePrefixFormat = "20060102-150405"
+	partialSuffix    = ".partial"
+)
+
+var AvailableListTypes = map[string]string{
+	ListTypeBoth: ListTypeBoth,
+	ListTypeTsv:  ListTypeTsv,
+	ListTypeMD:   ListTypeMD,
+}
+
+var (
+	listHeader = []string{
+		"No.",
+		"NumComment",
+		"Author",
+		"Publisher",
+		"Category",
+		"Title",
+		"Location",
+		"CollectedAt",
+		"PostedAt",
+		"ModifiedAt",
+		"Emotions",
+		"URL",
+		// appended last, so columns of lists before sections are where they were
+		"Section",
+	}
+	listHeaderLine = []string{
+		"---",
+		"---",
+		"---",
+		"---",
+		"---",
+		"---",
+		"---",
+		"---",
+		"---",
+		"---",
+		"---",
+		"---",
+		"---",
+	}
+)
+
+// Run locates the list and dump files of a single collection started at Started under RootPath.
+// Files of a Partial run, stopped by shutdown before it finished, are kept apart from complete ones.
+type Run struct {
+	RootPath string
+	Started  time.Time
+	Partial  bool
+}
+
+func (r Run) DumpPath() string {
+	return filepath.Join(r.RootPath, "dump", r.Started.Format(types.FileYearFormat))
+}
+
+func (r Run) FullDumpPath() string {
+	return filepath.Join(r.DumpPath(), r.Started.Format(types.FileDateFormat))
+}
+
+func (r Run) ListPath() string {
+	return filepath.Join(r.RootPath, "list", r.Started.Format(types.FileYearFormat), r.Started.Format(types.FileDateFormat))
+}
+
+func (r Run) FilePrefix() string {
+	if r.Partial {
+		return FilePrefix(r.Started) + partialSuffix
+	}
+
+	return FilePrefix(r.Started)
+}
+
+func (r Run) GzipDumpFile() string {
+	return filepath.Join(r.FullDumpPath(), fmt.Sprintf("%s.%s", r.FilePrefix(), "json.gz"))
+}
+
+// ClustersFile is the cluster report of the run kept next to its dump, which is not rewritten by clustering.
+func (r Run) ClustersFile() string {
+	return filepath.Join(r.FullDumpPath(), fmt.Sprintf("%s.%s", r.FilePrefix(), "clusters.json"))
+}
+
+// ParseRun restores a Run from a file prefix such as 20211016-040021.
+func ParseRun(rootPath string, prefix string) (Run, error) {
+	started, err := time.ParseInLocation(filePrefixFormat, prefix, time.Local)
+	if err != nil {
+		return Run{}, fmt.Errorf("run %s should be formatted as %s: %v", prefix, filePrefixFormat, err)
+	}
+
+	return Run{RootPath: rootPath, Started: started}, nil
+}
+
+// Runs finds runs of the json.gz dumps under rootPath, complete and partial ones, the earliest first.
+func Runs(rootPath string) ([]Run, error) {
+	files, err := filepath.Glob(filepath.Join(rootPath, "dump", "*", "*", "*.json.gz"))
+	if err != nil {
+		return nil, err
+	}
+
+	runs := make([]Run, 0, len(files))
+	for _, f := range files {
+		prefix := strings.TrimSuffix(filepath.Base(f), ".json.gz")
+		partial := strings.HasSuffix(prefix, partialSuffix)
+
+		run, errParse := ParseRun(rootPath, strings.TrimSuffix(prefix, partialSuffix))
+		if errParse != nil {
+			continue
+		}
+
+		run.Partial = partial
+		runs = append(runs, run)
+	}
+
+	sort.Slice(runs, func(i, j int) bool {
+		return runs[i].Started.Before(runs[j].Started)
+	})
+
+	return runs, nil
+}
+
+func FilePrefix(t time.Time) string {
+	return fmt.Sprintf("%s-%s", t.Format(types.FileDateFormat), t.Format(types.FileTimeFormat))
+}
+
+func WriteLists(news []types.News, listPath, filePrefix, listOutputFormat string) {
+	tableRows := ToTable(news)
+
+	switch listOutputFormat {
+	case ListTypeTsv:
+		DumpToFile(tableRows, listPath, filePrefix, "tsv", '\t', false)
+	case ListTypeMD:
+		DumpToFile(tableRows, listPath, filePrefix, "md", '|', true)
+	case ListTypeBoth:
+		DumpToFile(tableRows, listPath, filePrefix, "tsv", '\t', false)
+		DumpToFile(tableRows, listPath, filePrefix, "md", '|', true)
+	}
+}
+
+func WriteJsonGzip(news []types.News, gzipDumpFile string) error {
+	byteArr, errGzip := ToJsonGzipBytes(news)
+	if errGzip != nil {
+		return errGzip
+	}
+
+	// write aside and rename, so a collection stopped while writing never leaves a half written dump
+	tmpFile := gzipDumpFile + ".tmp"
+	if err := ioutil.WriteFile(tmpFile, byteArr, os.FileMode(0644)); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpFile, gzipDumpFile)
+}
+
+func ReadJsonGzip(gzipDumpFile string) ([]types.News, error) {
+	f, err := os.Open(gzipDumpFile)
+	if err != nil {
+		return nil, err
+	}
+	//goland:noinspection GoUnhandledErrorResult
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	//goland:noinspection GoUnhandledErrorResult
+	defer gz.Close()
+
+	news := make([]types.News, 0)
+	if err = json.NewDecoder(gz).Decode(&news); err != nil {
+		return nil, fmt.Errorf("failed to decode %s for %v", gzipDumpFile, err)
+	}
+
+	return news, nil
+}
+
+func ToJsonGzipBytes(news []types.News) ([]byte, error) {
+	jsonBytes, errJson := json.Marshal(news)
+	if errJson != nil {
+		return nil, errJson
+	}
+
+	buffer := &bytes.Buffer{}
+	gz, errGzip := gzip.NewWriterLevel(buffer, gzip.BestCompression)
+	if errGzip != nil {
+		return nil, errGzip
+	}
+
+	_, errGzip = gz.Write(jsonBytes)
+	if errGzip != nil {
+		return nil, errGzip
+	}
+
+	if errGzip = gz.Close(); errGzip != nil {
+		return nil, errGzip
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func ToTable(news []types.News) [][]string {
+	tableRows := make([][]string, 0)
+	for idx, n := range news {
+		emotions := make([]string, 0)
+		author := ""
+		publisher := n.Publisher
+		numComment := uint64(0)
+		title := strings.TrimSpace(n.Title)
+		location := n.Location
+		collectedAt := ""
+		postedAt := ""
+		modifiedAt := ""
+		category := ""
+
+		if n.End != nil {
+			author = n.End.Author
+			publisher = n.End.Provider
+			numComment = n.End.NumComment
+			category = n.End.Category
+			collectedAt = n.End.CollectedAt
+			postedAt = n.End.PostedAt
+			modifiedAt = n.End.ModifiedAt
+
+			for _, e := range n.End.Emotions {
+				if e.CountString == "" {
+					emotions = append(emotions, fmt.Sprintf("%s(%d)", e.Name, e.Count))
+				} else {
+					emotions = append(emotions, fmt.Sprintf("%s(%s)", e.Name, e.CountString))
+				}
+			}
+		}
+
+		if author == "" {
+			author = "-"
+		}
+
+		if publisher == "" {
+			publisher = "-"
+		}
+
+		if category == "" {
+			category = "-"
+		}
+
+		if postedAt == "" {
+			postedAt = "-"
+		}
+
+		if modifiedAt == "" {
+			modifiedAt = "-"
+		}
+
+		author = strings.TrimSpace(author)
+		publisher = strings.TrimSpace(publisher)
+
+		row := []string{
+			fmt.Sprintf("%d", idx),
+			fmt.Sprintf("%d", numComment),
+			author,
+			publisher,
+			category,
+			title,
+			string(location),
+			collectedAt,
+			postedAt,
+			modifiedAt,
+			emotionsToString(emotions),
+			n.URL,
+			sectionToString(n.Section),
+		}
+
+		tableRows = append(tableRows, row)
+	}
+	return tableRows
+}
+
+func sectionToString(s types.Section) string {
+	switch {
+	case s.ID == "":
+		return "-"
+	case s.Label == "":
+		return s.ID
+	default:
+		return fmt.Sprintf("%s(%s)", s.ID, s.Label)
+	}
+}
+
+func emotionsToString(emotions []string) string {
+	if len(emotions) == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%v", emotions)
+}
+
+func DumpToFile(rows [][]string, listPath, filePrefix, ext string, sep rune, headerLine bool) {
+	buffer := &bytes.Buffer{}
+	table := csv.NewWriter(buffer)
+	table.Comma = sep
+
+	if sep == '|' {
+		for i := range rows {
+			for j := range rows[i] {
+				rows[i][j] = strings.ReplaceAll(rows[i][j], "|", "&vert;")
+			}
+		}
+	}
+
+	_ = table.Write(listHeader)
+	if headerLine {
+		_ = table.Write(listHeaderLine)
+	}
+	_ = table.WriteAll(rows)
+	table.Flush()
+
+	outputFile := filepath.Join(listPath, fmt.Sprintf("%s.%s", filePrefix, ext))
+
+	if err := table.Error(); err != nil {
+		log.Printf("error occured when writing list of type %s %v", ext, err)
+	}
+
+	if err := ioutil.WriteFile(outputFile, buffer.Bytes(), os.FileMode(0600)); err != nil {
+		log.Println("failed to write to", outputFile, "for", err.Error())
+	}
+}
@@ -2,12 +2,38 @@ package test
 
 import (
 	"os"
+	"path/filepath"
+
+	"github.com/go-rod/rod"
+
+	"github.com/darimuri/coll-news/pkg/replay"
 )
 
 var LaunchHeadless bool = false
 
+// RecordDir and ReplayDir let suites record responses of live portals once and run against them later.
+var (
+	RecordDir string
+	ReplayDir string
+)
+
 func init() {
 	if os.Getenv("TEST_HEADLESS") != "" {
 		LaunchHeadless = true
 	}
+
+	RecordDir = os.Getenv("TEST_RECORD_DIR")
+	ReplayDir = os.Getenv("TEST_REPLAY_DIR")
+}
+
+// Hijack records or replays the responses of browser under name when TEST_RECORD_DIR or TEST_REPLAY_DIR is set.
+// The session returned is nil when neither is set.
+func Hijack(browser *rod.Browser, name string) (*replay.Session, error) {
+	if ReplayDir != "" {
+		return replay.Replay(browser, filepath.Join(ReplayDir, name))
+	} else if RecordDir != "" {
+		return replay.Record(browser, filepath.Join(RecordDir, name))
+	}
+
+	return nil, nil
 }
@@ -1,21 +1,30 @@
 package types
 
 import (
+	"context"
+
 	"github.com/darimuri/go-lib/rodtemplate"
 )
 
 type Collector interface {
-	Top()
-	NewsHome()
-	GetTopNewsList() ([]News, error)
-	GetNewsHomeNewsList() ([]News, error)
-	GetNewsEnd(n *News) error
+	Top(ctx context.Context)
+	NewsHome(ctx context.Context)
+	GetTopNewsList(ctx context.Context) ([]News, error)
+	GetNewsHomeNewsList(ctx context.Context) ([]News, error)
+	GetNewsEnd(ctx context.Context, n *News) error
+	GetNewsEnds(ctx context.Context, news []News, concurrency int, stopOnError bool) []error
+	Alive() error
 	Cleanup()
 }
 
 type TypedCollector interface {
-	PrepareNewsHomeScreenShot(p *rodtemplate.PageTemplate)
-	GetNewsHomeNewsList(p *rodtemplate.PageTemplate, dd DumpDirectory) ([]News, error)
-	GetTopNewsList(p *rodtemplate.PageTemplate, dd DumpDirectory) ([]News, error)
-	GetNewsEnd(p *rodtemplate.PageTemplate, n *News) error
+	PrepareNewsHomeScreenShot(ctx context.Context, p *rodtemplate.PageTemplate)
+	GetNewsHomeNewsList(ctx context.Context, p *rodtemplate.PageTemplate, dd DumpDirectory) ([]News, error)
+	GetTopNewsList(ctx context.Context, p *rodtemplate.PageTemplate, dd DumpDirectory) ([]News, error)
+	GetNewsEnd(ctx context.Context, p *rodtemplate.PageTemplate, n *News) error
+}
+
+// CommentCollector is a TypedCollector which also collects top comments of ends, after GetNewsEnd of the same page.
+type CommentCollector interface {
+	GetComments(ctx context.Context, p *rodtemplate.PageTemplate, n *News, option CommentOption) error
 }
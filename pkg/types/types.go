@@ -25,20 +25,47 @@ const (
 )
 
 type News struct {
-	URL            string `json:"url"`
-	Image          string `json:"image,omitempty"`
-	Title          string `json:"title"`
-	SeriesTitle    string `json:"series_title,omitempty"`
-	NewsPage       int    `json:"news_page"`
-	Order          int    `json:"order"`
-	SubOrder       int    `json:"sub_order"`
-	FullHTML       string `json:"full_html"`
-	FullScreenShot string `json:"full_screen_shot"`
-	TabScreenShot  string `json:"tab_screen_shot"`
-	Publisher      string `json:"publisher"`
-	Location       Loc    `json:"loc"`
-	CollectedAt    string `json:"collected_at"`
-	End            *End   `json:"end"`
+	URL            string       `json:"url"`
+	Image          string       `json:"image,omitempty"`
+	Title          string       `json:"title"`
+	SeriesTitle    string       `json:"series_title,omitempty"`
+	NewsPage       int          `json:"news_page"`
+	Section        Section      `json:"section"`
+	Tab            string       `json:"tab,omitempty"`
+	Demographic    *Demographic `json:"demographic,omitempty"`
+	Order          int          `json:"order"`
+	SubOrder       int          `json:"sub_order"`
+	FullHTML       string       `json:"full_html"`
+	FullScreenShot string       `json:"full_screen_shot"`
+	TabScreenShot  string       `json:"tab_screen_shot"`
+	Publisher      string       `json:"publisher"`
+	Location       Loc          `json:"loc"`
+	CollectedAt    string       `json:"collected_at"`
+	ClusterID      string       `json:"cluster_id,omitempty"`
+	End            *End         `json:"end"`
+
+	FieldErrors []FieldError `json:"field_errors,omitempty"`
+}
+
+// Section is the block of a list page a news is listed in. ID stays the same while the layout is
+// changed, Label is how the page names the block.
+type Section struct {
+	ID    string `json:"id"`
+	Label string `json:"label,omitempty"`
+}
+
+// Demographic is a reader segment, e.g. Gender female and Age 30s read from the label 30대 여성.
+// Gender and Age are empty when the label does not tell them.
+type Demographic struct {
+	Gender string `json:"gender,omitempty"`
+	Age    string `json:"age,omitempty"`
+	Label  string `json:"label"`
+}
+
+// FieldError records a field of News or End left empty because it could not be parsed.
+type FieldError struct {
+	Field string `json:"field"`
+	Error string `json:"error"`
 }
 
 type End struct {
@@ -56,6 +83,30 @@ type End struct {
 	Images      []string  `json:"images,omitempty"`
 	Program     string    `json:"program,omitempty"`
 	NumPlayed   uint64    `json:"num_played"`
+	Comments    []Comment `json:"comments,omitempty"`
+	CommentSort string    `json:"comment_sort,omitempty"`
+}
+
+const (
+	CommentSortRecommended = "recommended"
+	CommentSortLatest      = "latest"
+)
+
+// CommentOption asks top Limit comments of ends in Sort order. No comments are collected for Limit 0.
+type CommentOption struct {
+	Limit int
+	Sort  string
+}
+
+// Comment is a reader comment of an end. AuthorHash is a hash of the nickname, which tells comments of the
+// same author apart without keeping who they are.
+type Comment struct {
+	AuthorHash string `json:"author_hash"`
+	Text       string `json:"text"`
+	Likes      uint64 `json:"likes"`
+	Dislikes   uint64 `json:"dislikes"`
+	Replies    uint64 `json:"replies"`
+	PostedAt   string `json:"posted_at,omitempty"`
 }
 
 type Emotion struct {
@@ -76,6 +127,7 @@ type DumpDirectory struct {
 	RootPath string
 	Source   string
 	DumpTime time.Time
+	URL      string
 
 	dumpPath   string
 	dumpPrefix string
@@ -32,8 +32,12 @@ func ImgAltTryFromHTML(item *rodtemplate.ElementTemplate) string {
 
 func GetElementAttributeFromHTML(item *rodtemplate.ElementTemplate, selector string, attribute string) string {
 	el := item.El(selector)
-	html := el.MustHTML()
 
+	return AttributeFromHTML(el.MustHTML(), attribute)
+}
+
+// AttributeFromHTML reads attribute from html of an element, for attributes not reflected to the dom yet.
+func AttributeFromHTML(html string, attribute string) string {
 	if strings.Contains(html, attribute) {
 		split := strings.Split(html, " ")
 		for _, s := range split {
@@ -0,0 +1,79 @@
+package util
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ParseCount parses counters rendered with separators such as "1,234" or "댓글 12".
+func ParseCount(s string) (uint64, error) {
+	digits := strings.Builder{}
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			digits.WriteRune(r)
+		}
+	}
+
+	if digits.Len() == 0 {
+		return 0, fmt.Errorf("no digit in count string '%s'", s)
+	}
+
+	return strconv.ParseUint(digits.String(), 10, 64)
+}
+
+// NormalizeURL drops fragment, and query of portal articles, so the same article gets the same key across lists.
+func NormalizeURL(k string) (string, error) {
+	u, err := url.Parse(k)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse url %s for error: %v", k, err)
+	}
+
+	if u.RawQuery != "" && false == IsPortalArticle(u) {
+		return fmt.Sprintf("%s://%s%s?%s", u.Scheme, u.Host, u.Path, u.RawQuery), nil
+	}
+
+	return fmt.Sprintf("%s://%s%s", u.Scheme, u.Host, u.Path), nil
+}
+
+// IsPortalArticle tells u is an article of daum or naver with its id in the path, whose query only tells where it
+// was listed. Other sites such as press sites of the newsstand tell articles by query, e.g. articleView.html?idxno=1.
+func IsPortalArticle(u *url.URL) bool {
+	host := strings.ToLower(u.Hostname())
+
+	switch {
+	case host == "daum.net" || strings.HasSuffix(host, ".daum.net"):
+		return true
+	case host == "naver.com" || strings.HasSuffix(host, ".naver.com"):
+		return strings.Contains(u.Path, "/article/")
+	}
+
+	return false
+}
+
+// HashAuthor hashes a comment author nickname, so authors are told apart without being kept.
+func HashAuthor(nickname string) string {
+	nickname = strings.TrimSpace(nickname)
+	if nickname == "" {
+		return ""
+	}
+
+	sum := sha256.Sum256([]byte(nickname))
+
+	return hex.EncodeToString(sum[:8])
+}
+
+// URLHash names the article of a url in file names, the same for urls which normalize the same.
+func URLHash(k string) (string, error) {
+	key, err := NormalizeURL(k)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256([]byte(key))
+
+	return hex.EncodeToString(sum[:8]), nil
+}